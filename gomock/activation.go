@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// ActivationPolicy decides whether mocks are active in the running binary,
+// Invoke and Spy do nothing when it returns false.
+type ActivationPolicy func() bool
+
+// EnableEnv is the environment variable read by the FromEnv policy.
+const EnableEnv = "GOMOCK_ENABLE"
+
+// TestsOnly activates mocks in test binaries only, it's the default policy.
+func TestsOnly() bool {
+	return testBinary()
+}
+
+// FromEnv activates mocks in test binaries, and in other binaries if the
+// environment variable GOMOCK_ENABLE is set to 1 when they start.
+func FromEnv() bool {
+	return testBinary() || envEnabled()
+}
+
+// testBinary reports whether the running binary is a test binary.
+var testBinary = testing.Testing
+
+var envEnabled = sync.OnceValue(func() bool {
+	return os.Getenv(EnableEnv) == "1"
+})
+
+var policy atomic.Pointer[ActivationPolicy]
+
+// SetActivationPolicy sets the policy deciding whether mocks are active,
+// nil restores the default policy, TestsOnly.
+func SetActivationPolicy(p ActivationPolicy) {
+	if p == nil {
+		policy.Store(nil)
+		return
+	}
+	policy.Store(&p)
+}
+
+// active reports whether mocks are active according to the policy.
+func active() bool {
+	if p := policy.Load(); p != nil {
+		return (*p)()
+	}
+	return TestsOnly()
+}
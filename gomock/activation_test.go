@@ -0,0 +1,100 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+func TestActivationPolicy(t *testing.T) {
+	defer gomock.SetActivationPolicy(nil)
+
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	mockAll(r, NewMockClient(r))
+
+	// Test case: the default policy activates mocks in tests
+	resp, _ := c.Get(ctx, &Request{}, &Trace{})
+	assert.Equal(t, resp.Message, "")
+	assert.Equal(t, gomock.TestsOnly(), true)
+	assert.Equal(t, gomock.FromEnv(), true)
+
+	// Test case: a policy deactivating mocks
+	gomock.SetActivationPolicy(func() bool { return false })
+	resp, _ = c.Get(ctx, &Request{}, &Trace{})
+	assert.Equal(t, resp.Message, "9:xxx")
+	assert.Equal(t, len(r.AllCalls()), 1)
+
+	// Test case: the default policy is restored
+	gomock.SetActivationPolicy(nil)
+	resp, _ = c.Get(ctx, &Request{}, &Trace{})
+	assert.Equal(t, resp.Message, "")
+	assert.Equal(t, len(r.AllCalls()), 2)
+}
+
+func TestHistoryLimit(t *testing.T) {
+	defer gomock.SetActivationPolicy(nil)
+
+	var c Client
+
+	// Test case: mocks activated outside test binaries keep no history
+	{
+		gomock.SetTestBinary(t, false)
+		gomock.SetActivationPolicy(func() bool { return true })
+		r, ctx := gomock.Init(context.Background())
+		mockAll(r, NewMockClient(r))
+		for range 3 {
+			resp, _ := c.Get(ctx, &Request{}, &Trace{})
+			assert.Equal(t, resp.Message, "")
+		}
+		assert.Equal(t, len(r.AllCalls()), 0)
+		gomock.SetTestBinary(t, true)
+	}
+
+	// Test case: a limit keeps the last calls
+	{
+		r, ctx := gomock.Init(context.Background())
+		mockAll(r, NewMockClient(r))
+		r.SetHistoryLimit(2)
+		for _, token := range []string{"1", "2", "3"} {
+			_, _ = c.Get(ctx, &Request{Token: token}, &Trace{})
+		}
+		calls := r.AllCalls()
+		assert.Equal(t, len(calls), 2)
+		assert.Equal(t, calls[0].Params[1].(*Request).Token, "2")
+		assert.Equal(t, calls[1].Params[1].(*Request).Token, "3")
+
+		// Test case: lowering the limit drops the oldest calls
+		r.SetHistoryLimit(1)
+		calls = r.AllCalls()
+		assert.Equal(t, len(calls), 1)
+		assert.Equal(t, calls[0].Params[1].(*Request).Token, "3")
+
+		// Test case: a negative limit keeps every call
+		r.SetHistoryLimit(-1)
+		for range 3 {
+			_, _ = c.Get(ctx, &Request{}, &Trace{})
+		}
+		assert.Equal(t, len(r.AllCalls()), 4)
+	}
+}
@@ -0,0 +1,407 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lvan100/gomock/gomock/match"
+)
+
+// ExceedPolicy decides what happens when a mocker is called more times than
+// its expectation allows.
+type ExceedPolicy int
+
+const (
+	// ExceedStopMatching makes the mocker stop matching once the maximum is
+	// reached, so that later mockers get a chance to handle the call. The
+	// extra calls accepted by the When condition of the mocker are still
+	// reported as an unmet expectation in Manager.Verify.
+	ExceedStopMatching = ExceedPolicy(iota)
+	// ExceedFail keeps the mocker matching and reports the extra calls as an
+	// unmet expectation in Manager.Verify.
+	ExceedFail
+)
+
+// ExhaustPolicy decides what a mocker does once its sequence of returns,
+// set by ReturnSequence or Then, is exhausted.
+type ExhaustPolicy int
+
+const (
+	// ExhaustRepeatLast makes the mocker repeat the last return.
+	ExhaustRepeatLast = ExhaustPolicy(iota)
+	// ExhaustStopMatching makes the mocker stop matching, so that later
+	// mockers get a chance to handle the call.
+	ExhaustStopMatching
+	// ExhaustFail reports a failure, then repeats the last return.
+	ExhaustFail
+)
+
+// base holds the state shared by all generated mockers.
+type base struct {
+	mu sync.Mutex // guards the mutable fields of the base and of its mocker
+
+	r          *Manager
+	reg        *Registration // the registration of the mocker in r, see Remove
+	typ        reflect.Type
+	method     string
+	paramTypes []reflect.Type // the types of the parameters of the method
+	args       []arg          // the parameter matchers, if any
+
+	expected bool // whether any call-count expectation was set
+	min      int  // minimum number of calls
+	max      int  // maximum number of calls, negative means unlimited
+	count    int  // number of calls handled
+	overflow int  // number of calls rejected because max was reached, see ExceedStopMatching
+	uses     int  // number of calls after which the mocker retires, negative means never
+	priority int  // mockers with a higher priority are tried first
+	spy      bool // whether the mocker observes the real implementation instead of replacing it
+
+	seqs []*Sequence // the sequences this mocker belongs to
+
+	exhaust  ExhaustPolicy // what to do once the sequence of returns is exhausted
+	nreturns int           // number of returns in the sequence, see ReturnSequence
+	next     int           // index of the next return in the sequence
+
+	delay      func(params []interface{}) time.Duration // the latency injected into each call, if any
+	panics     bool                                     // whether each call panics with panicValue
+	panicValue interface{}
+	setters    []setter    // the values assigned through the parameters, see setArg
+	captors    []capturing // the captors attached to the parameters
+}
+
+// newBase creates a base for a mocker of the given type and method.
+func newBase(r *Manager, typ reflect.Type, method string, paramTypes []reflect.Type) base {
+	return base{r: r, typ: typ, method: method, paramTypes: paramTypes, max: -1, uses: -1}
+}
+
+// getBase returns the base itself, it's used to reach the base through an Invoker.
+func (b *base) getBase() *base {
+	return b
+}
+
+// setMin sets the minimum number of calls.
+func (b *base) setMin(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expected = true
+	b.min = n
+}
+
+// setMax sets the maximum number of calls.
+func (b *base) setMax(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expected = true
+	b.max = n
+}
+
+// claim records a call matched by the mocker, unless the mocker is no longer
+// available, see available, or its sequence of returns is drained, which may
+// happen when calls are concurrent. It returns the index of the return to
+// use in the sequence, taken in the same critical section, see returnAt.
+func (b *base) claim(stop bool) (int, bool) {
+	b.mu.Lock()
+	if b.retired() || (stop && b.exhausted()) {
+		b.mu.Unlock()
+		b.overflowed(stop)
+		return 0, false
+	}
+	if b.nreturns > 0 && b.exhaust == ExhaustStopMatching && b.next >= b.nreturns {
+		b.mu.Unlock()
+		return 0, false
+	}
+	b.count++
+	i := b.next
+	b.next++
+	b.mu.Unlock()
+	b.settle(true)
+	return i, true
+}
+
+// reserve counts a call the mocker may handle, unless the mocker is no
+// longer available, see available. It's used when whether the call is
+// handled is only known after calling the mocker, the call must then be
+// settled.
+func (b *base) reserve(stop bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.retired() || (stop && b.exhausted()) {
+		return false
+	}
+	b.count++
+	return true
+}
+
+// settle completes a call counted by reserve, it's reported to the sequences
+// of the mocker if handled, and given back otherwise.
+func (b *base) settle(handled bool) {
+	b.mu.Lock()
+	if !handled {
+		b.count--
+		b.mu.Unlock()
+		return
+	}
+	seqs := b.seqs
+	b.mu.Unlock()
+	for _, s := range seqs {
+		s.called(b)
+	}
+}
+
+// capped reports whether the mocker stops matching because it has reached
+// its maximum number of calls, which stop tells to do.
+func (b *base) capped(stop bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return stop && b.exhausted()
+}
+
+// overflowed records a call matched by the mocker but rejected because it
+// has reached its maximum number of calls, it's reported by unmet.
+func (b *base) overflowed(stop bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stop && b.exhausted() {
+		b.overflow++
+	}
+}
+
+// available reports whether the mocker may handle a call, it may not once it
+// has retired or, if stop is true, reached its maximum number of calls.
+func (b *base) available(stop bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.retired() && !(stop && b.exhausted())
+}
+
+// progress returns the number of calls handled and whether it's enough to
+// let the next step of a sequence proceed.
+func (b *base) progress() (count int, satisfied bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count, b.satisfied()
+}
+
+// satisfied reports whether the mocker has been called enough times to let
+// the next step of a sequence proceed, b.mu must be held.
+func (b *base) satisfied() bool {
+	if b.expected {
+		return b.count >= b.min
+	}
+	return b.count > 0
+}
+
+// injectedPanic wraps the value of a panic injected by Panic, so that the
+// Manager can record the call before raising the panic again.
+type injectedPanic struct {
+	value interface{}
+}
+
+// inject captures the parameters, then applies the latency, the parameter
+// assignments and the panic injected into the mocker. It
+// returns the context error instead if the latency is aborted because the
+// first parameter is a context that gets cancelled.
+func (b *base) inject(params []interface{}) error {
+	b.captureArgs(params)
+	b.mu.Lock()
+	delay, panics, panicValue := b.delay, b.panics, b.panicValue
+	b.mu.Unlock()
+	if delay != nil {
+		if err := sleep(params, delay(params)); err != nil {
+			return err
+		}
+	}
+	b.assignArgs(params)
+	if panics {
+		panic(injectedPanic{panicValue})
+	}
+	return nil
+}
+
+// sleep waits for d, or until the first parameter, if it's a context, is done.
+func sleep(params []interface{}, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	var ctx context.Context
+	if len(params) > 0 {
+		ctx, _ = params[0].(context.Context)
+	}
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// errorOrZero returns err if R is the error type, or the zero value of R.
+func errorOrZero[R any](err error) R {
+	var v R
+	if p, ok := any(&v).(*error); ok {
+		*p = err
+	}
+	return v
+}
+
+// paramAt returns the i-th parameter of a call to the mocker as a T, nil is
+// converted to the zero value of T, such as a nil error or context. It panics
+// with the method name and the parameter index if the parameter is not a T.
+func paramAt[T any](b *base, params []interface{}, i int) T {
+	if i >= len(params) {
+		panic(fmt.Sprintf("gomock: %s.%s: missing parameter %d, got %d parameters", b.typ, b.method, i+1, len(params)))
+	}
+	v, ok := params[i].(T)
+	if !ok && params[i] != nil {
+		panic(fmt.Sprintf("gomock: %s.%s: unexpected type of parameter %d: got %T, want %s", b.typ, b.method, i+1, params[i], reflect.TypeFor[T]()))
+	}
+	return v
+}
+
+// resultAt returns the i-th result as a T, or the zero value of T if the
+// result is missing or nil.
+func resultAt[T any](results []interface{}, i int) T {
+	var v T
+	if i < len(results) {
+		v, _ = results[i].(T)
+	}
+	return v
+}
+
+// arg is a parameter matcher with its type erased.
+type arg struct {
+	desc    string
+	explain func(v interface{}) string
+}
+
+// newArg creates an arg from a typed matcher.
+func newArg[T any](m match.Matcher[T]) arg {
+	return arg{
+		desc: m.String(),
+		explain: func(v interface{}) string {
+			t, _ := v.(T)
+			return match.Explain(m, t)
+		},
+	}
+}
+
+// matchers returns the parameter matchers.
+func (b *base) matchers() []arg {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.args
+}
+
+// explain returns why the parameter matchers reject the given parameters.
+func (b *base) explain(params []interface{}) string {
+	var ss []string
+	for i, a := range b.matchers() {
+		if i >= len(params) {
+			break
+		}
+		if s := a.explain(params[i]); s != "" {
+			ss = append(ss, fmt.Sprintf("arg %d: %s", i+1, s))
+		}
+	}
+	return strings.Join(ss, "; ")
+}
+
+// String returns the type and method of the mocker, followed by its
+// parameter matchers if any.
+func (b *base) String() string {
+	args := b.matchers()
+	if len(args) == 0 {
+		return fmt.Sprintf("%s.%s", b.typ, b.method)
+	}
+	ss := make([]string, len(args))
+	for i, a := range args {
+		ss[i] = a.desc
+	}
+	return fmt.Sprintf("%s.%s(%s)", b.typ, b.method, strings.Join(ss, ", "))
+}
+
+// nextReturn takes the index of the next return in the sequence, for the
+// calls that aren't claimed, see claim.
+func (b *base) nextReturn() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	i := b.next
+	b.next++
+	return i
+}
+
+// pickReturn returns the index of the return to use in a sequence of n
+// returns for the i-th call, counted from 0, once the sequence is exhausted.
+func (b *base) pickReturn(i, n int) int {
+	if i < n {
+		return i
+	}
+	b.mu.Lock()
+	fail := b.exhaust == ExhaustFail
+	b.mu.Unlock()
+	if fail {
+		b.r.errorf("%s: called %d times but only %d returns were set", b, i+1, n)
+	}
+	return n - 1
+}
+
+// exhausted reports whether the mocker has reached its maximum number of
+// calls, b.mu must be held.
+func (b *base) exhausted() bool {
+	return b.max >= 0 && b.count >= b.max
+}
+
+// retired reports whether the mocker has handled all the calls it may use,
+// b.mu must be held.
+func (b *base) retired() bool {
+	return b.uses >= 0 && b.count >= b.uses
+}
+
+// unmet returns a description of the unmet expectation, or an empty string
+// if the expectation is met.
+func (b *base) unmet() string {
+	b.mu.Lock()
+	expected, count, min, max := b.expected, b.count+b.overflow, b.min, b.max
+	b.mu.Unlock()
+	if !expected || (count >= min && (max < 0 || count <= max)) {
+		return ""
+	}
+	var want string
+	switch {
+	case min == max:
+		want = fmt.Sprintf("exactly %d", min)
+	case max < 0:
+		want = fmt.Sprintf("at least %d", min)
+	case min == 0:
+		want = fmt.Sprintf("at most %d", max)
+	default:
+		want = fmt.Sprintf("between %d and %d", min, max)
+	}
+	return fmt.Sprintf("%s: expected %s call(s), got %d", b, want, count)
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Captor accumulates the values passed at a parameter position of the calls
+// answered by mockers, it's attached to them by their Capture method.
+// The zero value is ready to use.
+type Captor[T any] struct {
+	mu     sync.Mutex
+	values []T
+}
+
+// Last returns the last value captured, or the zero value of T if none.
+func (c *Captor[T]) Last() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var v T
+	if n := len(c.values); n > 0 {
+		v = c.values[n-1]
+	}
+	return v
+}
+
+// All returns the values captured, in the order of the calls.
+func (c *Captor[T]) All() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]T(nil), c.values...)
+}
+
+// Len returns the number of values captured.
+func (c *Captor[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.values)
+}
+
+// capture adds a value to the captor.
+func (c *Captor[T]) capture(v interface{}) {
+	t, _ := v.(T)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values = append(c.values, t)
+}
+
+// valueType returns the type of the values captured.
+func (c *Captor[T]) valueType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// Capturer is implemented by all Captors, whatever the type of their values,
+// it's the type of the captor passed to the Capture method of mockers.
+type Capturer interface {
+	capture(v interface{})
+	valueType() reflect.Type
+}
+
+// capturing is a Capturer attached to a parameter position.
+type capturing struct {
+	index  int
+	captor Capturer
+}
+
+// addCaptor attaches c to the i-th parameter, it panics if the parameter
+// can't be captured as the type of the values of c.
+func (b *base) addCaptor(i int, c Capturer) {
+	if i < 0 || i >= len(b.paramTypes) {
+		panic(fmt.Sprintf("gomock: %s.%s: Capture(%d): the method has %d parameters", b.typ, b.method, i, len(b.paramTypes)))
+	}
+	if t := b.paramTypes[i]; !t.AssignableTo(c.valueType()) {
+		panic(fmt.Sprintf("gomock: %s.%s: Capture(%d): can't capture a parameter of type %s as %s", b.typ, b.method, i, t, c.valueType()))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.captors = append(b.captors, capturing{index: i, captor: c})
+}
+
+// captureArgs adds the parameters to the attached captors.
+func (b *base) captureArgs(params []interface{}) {
+	b.mu.Lock()
+	captors := b.captors
+	b.mu.Unlock()
+	for _, c := range captors {
+		c.captor.capture(params[c.index])
+	}
+}
@@ -0,0 +1,59 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+func TestCaptor(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+
+	var (
+		traces gomock.Captor[*Trace]
+		reqs   gomock.Captor[any]
+	)
+	m1, _, _ := mockAll(r, NewMockClient(r))
+	m1.Capture(2, &traces).Capture(1, &reqs)
+
+	assert.Nil(t, traces.Last())
+	assert.Equal(t, traces.Len(), 0)
+
+	_, _ = c.Get(ctx, &Request{Token: "1"}, &Trace{TraceId: "t1"})
+	_, _ = c.Get(ctx, &Request{Token: "2"}, &Trace{TraceId: "t2"})
+
+	assert.Equal(t, traces.Len(), 2)
+	assert.Equal(t, traces.Last().TraceId, "t2")
+	assert.Equal(t, traces.All(), []*Trace{{TraceId: "t1"}, {TraceId: "t2"}})
+	assert.Equal(t, reqs.All(), []any{&Request{Token: "1"}, &Request{Token: "2"}})
+
+	// Test case: the captor doesn't match the parameter
+	assert.Panic(t, func() {
+		var tokens gomock.Captor[string]
+		m1.Capture(1, &tokens)
+	}, `gomock: gomock_test.Client.Get: Capture\(1\): can't capture a parameter of type \*gomock_test.Request as string`)
+	assert.Panic(t, func() {
+		m1.Capture(3, &traces)
+	}, `gomock: gomock_test.Client.Get: Capture\(3\): the method has 3 parameters`)
+}
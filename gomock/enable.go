@@ -0,0 +1,29 @@
+//go:build gomock_enable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+// Enable activates mocks in any binary, such as a service run locally for
+// end-to-end tests or demos, by replacing the activation policy. It's only
+// available in binaries built with the gomock_enable tag, so that production
+// binaries can't activate mocks by accident.
+func Enable() {
+	SetActivationPolicy(func() bool {
+		return true
+	})
+}
@@ -0,0 +1,40 @@
+//go:build gomock_enable && !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+func TestEnable(t *testing.T) {
+	defer gomock.SetActivationPolicy(nil)
+
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	mockAll(r, NewMockClient(r))
+
+	gomock.SetActivationPolicy(func() bool { return false })
+	gomock.Enable()
+	resp, _ := c.Get(ctx, &Request{}, &Trace{})
+	assert.Equal(t, resp.Message, "")
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"testing"
+)
+
+// SetTestBinary makes the package behave as if it ran in a test binary or
+// not, until the end of the test t.
+func SetTestBinary(t testing.TB, b bool) {
+	testBinary = func() bool { return b }
+	t.Cleanup(func() {
+		testBinary = testing.Testing
+	})
+}
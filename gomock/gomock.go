@@ -18,8 +18,12 @@ package gomock
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -29,6 +33,7 @@ type Mode int
 const (
 	ModeHandle = Mode(iota)
 	ModeWhenReturn
+	ModeSpy
 )
 
 var managerKey int
@@ -44,11 +49,45 @@ func getManager(ctx context.Context) *Manager {
 // Init initializes a new Manager and embeds it into the given context.
 func Init(ctx context.Context) (*Manager, context.Context) {
 	r := &Manager{
-		mockers: make(map[mockerKey][]Invoker),
+		mockers: make(map[mockerKey][]*Registration),
+		history: defaultHistoryLimit(),
 	}
 	return r, context.WithValue(ctx, &managerKey, r)
 }
 
+// New creates a new Manager bound to the lifetime of the test t, and embeds
+// it into a context derived from t.Context(). When the test ends, unmet
+// expectations and unmatched calls are reported through t, and the Manager
+// becomes unusable.
+func New(t testing.TB) (*Manager, context.Context) {
+	r, ctx := Init(t.Context())
+	r.bind(t)
+	return r, ctx
+}
+
+// bind binds the Manager to the lifetime of the test t, see New.
+func (r *Manager) bind(t testing.TB) {
+	r.t = t
+	t.Cleanup(func() {
+		t.Helper()
+		r.Verify(t)
+		r.reportUnmatched(t)
+		r.mu.Lock()
+		r.closed = true
+		r.mu.Unlock()
+	})
+}
+
+// Child creates a child of the Manager embedded in the given context, or
+// a new Manager if there is none, and embeds it into a derived context.
+func Child(ctx context.Context) (*Manager, context.Context) {
+	if p := getManager(ctx); p != nil {
+		r := p.Child()
+		return r, context.WithValue(ctx, &managerKey, r)
+	}
+	return Init(ctx)
+}
+
 // Invoker defines the interface that all mock implementations must satisfy.
 type Invoker interface {
 	// Mode returns the mocking mode
@@ -61,113 +100,670 @@ type Invoker interface {
 	Handle(params []interface{}) ([]interface{}, bool)
 }
 
+// Spier is implemented by Invokers that can observe the real implementation
+// of a method instead of replacing it, they are used when Mode returns ModeSpy.
+type Spier interface {
+	// Spy observes the real results and returns the results to use, and whether the spy applies
+	Spy(params []interface{}, results []interface{}) ([]interface{}, bool)
+}
+
+// Mocker is implemented by all generated mockers and their Invokers.
+type Mocker interface {
+	getBase() *base
+}
+
+// returner is implemented by the generated Invokers, it provides the values
+// of a return of the sequence taken when the call was claimed, see claim.
+type returner interface {
+	returnAt(params []interface{}, i int) []interface{}
+}
+
+// acceptor is implemented by the generated Invokers, it tells whether the
+// condition of a mocker accepts a call, even once the mocker stopped matching.
+type acceptor interface {
+	accepts(params []interface{}) bool
+}
+
 // mockerKey is used as a key in the map to identify mockers by type and method.
 type mockerKey struct {
 	typ    reflect.Type
 	method string
 }
 
+// Call records a single invocation routed through a Manager.
+type Call struct {
+	Type    reflect.Type  // the mocked type
+	Method  string        // the mocked method
+	Params  []interface{} // the parameters of the call
+	Results []interface{} // the results returned by the mock or, if spied, by the real implementation
+	Invoker Invoker       // the Invoker that answered the call, nil if not matched
+	Matched bool          // whether any Invoker answered the call
+	Spied   bool          // whether the real implementation handled the call, see Spy
+
+	reported bool // whether the call was reported when made, see InvokeMock
+}
+
 // Manager manages a collection of mockers for different types and methods.
+// It's safe for concurrent use, mockers may be registered while others are invoked.
 type Manager struct {
-	mockers map[mockerKey][]Invoker
+	mu      sync.Mutex // guards the fields below, except t and parent
+	mockers map[mockerKey][]*Registration
+	calls   []*Call
+	pending map[pendingKey][]*Call // the calls waiting for Spy, see recordSpied
+	history int                    // the maximum number of calls kept, negative if unlimited
+	seqs    []*Sequence            // the sequences joined by its mockers, see Verify
+	exceed  ExceedPolicy
+	prec    Precedence
+	strict  bool
+	closed  bool
+	t       testing.TB
+	parent  *Manager
+}
+
+// Child creates a new Manager whose lookups consult its own mockers first,
+// then fall back to those of r. Mockers added to the child don't affect r,
+// and calls made through the child are recorded in the child only. If r is
+// bound to a test, so is the child, which is verified when the test ends.
+func (r *Manager) Child() *Manager {
+	r.mu.Lock()
+	c := &Manager{
+		mockers: make(map[mockerKey][]*Registration),
+		history: r.history,
+		exceed:  r.exceed,
+		prec:    r.prec,
+		strict:  r.strict,
+		parent:  r,
+	}
+	r.mu.Unlock()
+	if r.t != nil {
+		c.bind(r.t)
+	}
+	return c
+}
+
+// isClosed reports whether the Manager or one of its ancestors is closed.
+func (r *Manager) isClosed() bool {
+	for ; r != nil; r = r.parent {
+		r.mu.Lock()
+		closed := r.closed
+		r.mu.Unlock()
+		if closed {
+			return true
+		}
+	}
+	return false
+}
+
+// Precedence decides the order in which the mockers registered for the same
+// type and method are tried. Mockers with a higher Priority are always tried
+// first, the Precedence only orders mockers of the same priority.
+type Precedence int
+
+const (
+	// FirstWins tries the mockers in registration order.
+	FirstWins = Precedence(iota)
+	// LastWins tries the mockers in reverse registration order, so that
+	// mockers registered in a test body override the defaults of a setup helper.
+	LastWins
+)
+
+// SetPrecedence sets the order in which the mockers are tried, the default is FirstWins.
+func (r *Manager) SetPrecedence(p Precedence) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prec = p
+}
+
+// SetStrict sets whether the Manager is strict about the results of its
+// mocks. In strict mode, Invoke and Spy check the results of a call against
+// the result types of the mocked method, and report a mismatch of their
+// number or types through the test bound to the Manager, with the method
+// name, then return zero values instead, which UnboxN accepts. Otherwise the
+// mismatch is only logged by UnboxN. The methods that reflection can't find,
+// such as unexported ones, aren't checked. Strict mode requires a Manager
+// created by New, it panics otherwise.
+func (r *Manager) SetStrict(strict bool) {
+	if strict && r.t == nil {
+		panic("gomock: strict mode requires a Manager created by New")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strict = strict
+}
+
+// checkResults checks the results of a call of the given method in strict
+// mode, and returns zero values instead if they don't match, see SetStrict.
+func (r *Manager) checkResults(typ reflect.Type, method string, ret []interface{}) []interface{} {
+	types, ok := resultTypes(typ, method)
+	if !ok {
+		return ret
+	}
+	if err := checkResults(ret, types); err != nil {
+		r.errorf("%s.%s: %v", typ, method, err)
+		return make([]interface{}, len(types))
+	}
+	return ret
+}
+
+// isStrict reports whether the Manager is strict, see SetStrict.
+func (r *Manager) isStrict() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.strict
+}
+
+// ordered returns the mockers of a given type and method in the order they are tried.
+func (r *Manager) ordered(typ reflect.Type, method string) []Invoker {
+	r.mu.Lock()
+	mockers := invokers(r.mockers[mockerKey{typ, method}])
+	prec := r.prec
+	r.mu.Unlock()
+	if prec == LastWins {
+		slices.Reverse(mockers)
+	}
+	sort.SliceStable(mockers, func(i, j int) bool {
+		return priority(mockers[i]) > priority(mockers[j])
+	})
+	return mockers
+}
+
+// priority returns the priority of an Invoker, 0 if it has none.
+func priority(f Invoker) int {
+	if m, ok := f.(Mocker); ok {
+		b := m.getBase()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.priority
+	}
+	return 0
+}
+
+// lookup returns the mockers of a given type and method in the order they
+// are tried, the inherited ones come after those of the Manager itself.
+func (r *Manager) lookup(typ reflect.Type, method string) []Invoker {
+	mockers := r.ordered(typ, method)
+	if r.parent != nil {
+		mockers = append(mockers, r.parent.lookup(typ, method)...)
+	}
+	return mockers
+}
+
+// errorf reports a failure through the test bound to the Manager,
+// or panics if there is none.
+func (r *Manager) errorf(format string, args ...interface{}) {
+	msg := "gomock: " + fmt.Sprintf(format, args...)
+	if r.t == nil {
+		panic(msg)
+	}
+	r.t.Helper()
+	r.t.Error(msg)
+}
+
+// defaultHistoryLimit returns the history limit of a new Manager: test
+// binaries keep every call, and other binaries, such as services whose mocks
+// are activated by FromEnv or Enable, keep none, so that the history doesn't
+// grow for as long as they run.
+func defaultHistoryLimit() int {
+	if testBinary() {
+		return -1
+	}
+	return 0
+}
+
+// SetHistoryLimit sets the maximum number of calls kept in the call history,
+// the oldest calls being dropped first. 0 disables the history, and a
+// negative limit keeps every call, which is the default in test binaries.
+// Outside test binaries the history is disabled by default. Calls that no
+// longer are in the history aren't reported as unmatched when a test ends.
+func (r *Manager) SetHistoryLimit(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.history = n
+	r.trimHistory()
+}
+
+// trimHistory drops the oldest calls beyond the history limit, r.mu must be held.
+func (r *Manager) trimHistory() {
+	if r.history < 0 || len(r.calls) <= r.history {
+		return
+	}
+	n := len(r.calls) - r.history
+	clear(r.calls[:n])
+	r.calls = r.calls[n:]
+	if r.history == 0 {
+		r.calls = nil
+		r.pending = nil
+	}
+}
+
+// SetExceedPolicy sets what happens when a mocker is called more times
+// than its expectation allows, the default is ExceedStopMatching.
+func (r *Manager) SetExceedPolicy(p ExceedPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exceed = p
+}
+
+// stopWhenExceeded reports whether mockers stop matching once they reach
+// their maximum number of calls, see SetExceedPolicy.
+func (r *Manager) stopWhenExceeded() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exceed == ExceedStopMatching
 }
 
-// GetMockers retrieves all mockers for a given type and method.
+// GetMockers retrieves all mockers for a given type and method, excluding
+// those inherited from the parent.
 func (r *Manager) GetMockers(typ reflect.Type, method string) []Invoker {
-	return r.mockers[mockerKey{typ, method}]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return invokers(r.mockers[mockerKey{typ, method}])
 }
 
-// AddMocker adds a new mocker for a specific type and method.
-func (r *Manager) AddMocker(typ reflect.Type, method string, i Invoker) {
+// AddMocker adds a new mocker for a specific type and method, and returns
+// a Registration that can be used to remove it.
+func (r *Manager) AddMocker(typ reflect.Type, method string, i Invoker) *Registration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		panic("gomock: Manager used after the test ended")
+	}
 	k := mockerKey{typ, method}
-	r.mockers[k] = append(r.mockers[k], i)
+	g := &Registration{r: r, key: k, invoker: i}
+	r.mockers[k] = append(r.mockers[k], g)
+	return g
 }
 
-// Invoke finds a matching Invoker and calls it based on the mocking mode.
-func Invoke(r *Manager, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
-	if r == nil || !testing.Testing() {
-		return nil, false
+// invokers returns the Invokers of the given registrations, in the same order.
+func invokers(regs []*Registration) []Invoker {
+	ret := make([]Invoker, len(regs))
+	for i, g := range regs {
+		ret[i] = g.invoker
 	}
-	mockers := r.GetMockers(typ, method)
-	for _, f := range mockers {
-		switch f.Mode() {
-		case ModeHandle:
-			ret, ok := f.Handle(params)
-			if ok {
-				return ret, true
-			}
-		case ModeWhenReturn:
-			if f.When(params) {
-				ret := f.Return(params)
-				return ret, true
-			}
-		default: // for linter
+	return ret
+}
+
+// Registration represents a mocker added to a Manager.
+type Registration struct {
+	r       *Manager
+	key     mockerKey
+	invoker Invoker
+	removed bool
+}
+
+// Remove removes the mocker from the Manager, it does nothing if the mocker
+// has already been removed. Only this registration is removed, even if the
+// same Invoker has been added several times.
+func (g *Registration) Remove() {
+	g.r.mu.Lock()
+	defer g.r.mu.Unlock()
+	if g.removed {
+		return
+	}
+	g.removed = true
+	mockers := g.r.mockers[g.key]
+	for i, f := range mockers {
+		if f == g {
+			mockers = append(mockers[:i:i], mockers[i+1:]...)
+			break
 		}
 	}
-	return nil, false
+	if len(mockers) == 0 {
+		delete(g.r.mockers, g.key)
+	} else {
+		g.r.mockers[g.key] = mockers
+	}
 }
 
-// InvokeContext is a convenience function that invokes a mock using context to retrieve the Manager.
-func InvokeContext(ctx context.Context, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
-	return Invoke(getManager(ctx), typ, method, params...)
+// Reset removes all mockers and clears the call history.
+func (r *Manager) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mockers = make(map[mockerKey][]*Registration)
+	r.calls = nil
+	r.pending = nil
 }
 
-// Unbox1 extracts a single return value from a slice of interfaces.
-func Unbox1[R1 any](ret []interface{}) (r1 R1) {
-	if len(ret) == 1 {
-		r1, _ = ret[0].(R1)
-	} else {
-		log.Printf("Warning: unexpected number of return values: %d", len(ret))
+// ResetType removes the mockers of a given type and clears its call history.
+func (r *Manager) ResetType(typ reflect.Type) {
+	r.reset(func(k mockerKey) bool { return k.typ == typ })
+}
+
+// ResetMethod removes the mockers of a given type and method and clears its call history.
+func (r *Manager) ResetMethod(typ reflect.Type, method string) {
+	r.reset(func(k mockerKey) bool { return k.typ == typ && k.method == method })
+}
+
+// reset removes the mockers and the calls whose key satisfies fn.
+func (r *Manager) reset(fn func(k mockerKey) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k := range r.mockers {
+		if fn(k) {
+			delete(r.mockers, k)
+		}
+	}
+	calls := r.calls[:0:0]
+	for _, c := range r.calls {
+		if !fn(mockerKey{c.Type, c.Method}) {
+			calls = append(calls, c)
+		}
+	}
+	r.calls = calls
+	for k := range r.pending {
+		if fn(mockerKey{k.typ, k.method}) {
+			delete(r.pending, k)
+		}
 	}
-	return
 }
 
-// Unbox2 extracts two return values from a slice of interfaces.
-func Unbox2[R1, R2 any](ret []interface{}) (r1 R1, r2 R2) {
-	if len(ret) == 2 {
-		r1, _ = ret[0].(R1)
-		r2, _ = ret[1].(R2)
-	} else {
-		log.Printf("Warning: unexpected number of return values: %d", len(ret))
+// Calls returns the recorded calls of a given type and method, in the order they were made.
+func (r *Manager) Calls(typ reflect.Type, method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []Call
+	for _, c := range r.calls {
+		if c.Type == typ && c.Method == method {
+			ret = append(ret, *c)
+		}
 	}
-	return
+	return ret
 }
 
-// Unbox3 extracts three return values from a slice of interfaces.
-func Unbox3[R1, R2, R3 any](ret []interface{}) (r1 R1, r2 R2, r3 R3) {
-	if len(ret) == 3 {
-		r1, _ = ret[0].(R1)
-		r2, _ = ret[1].(R2)
-		r3, _ = ret[2].(R3)
-	} else {
-		log.Printf("Warning: unexpected number of return values: %d", len(ret))
+// AllCalls returns all recorded calls, in the order they were made.
+func (r *Manager) AllCalls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := make([]Call, len(r.calls))
+	for i, c := range r.calls {
+		ret[i] = *c
 	}
-	return
+	return ret
 }
 
-// Unbox4 extracts four return values from a slice of interfaces.
-func Unbox4[R1, R2, R3, R4 any](ret []interface{}) (r1 R1, r2 R2, r3 R3, r4 R4) {
-	if len(ret) == 4 {
-		r1, _ = ret[0].(R1)
-		r2, _ = ret[1].(R2)
-		r3, _ = ret[2].(R3)
-		r4, _ = ret[3].(R4)
-	} else {
-		log.Printf("Warning: unexpected number of return values: %d", len(ret))
+// pendingKey identifies a call waiting for Spy by its method and the slice
+// of its parameters, see recordSpied.
+type pendingKey struct {
+	typ    reflect.Type
+	method string
+	params *interface{} // the first element of the parameters, nil if none
+}
+
+// newPendingKey returns the key of a call made with the given parameters.
+func newPendingKey(typ reflect.Type, method string, params []interface{}) pendingKey {
+	k := pendingKey{typ: typ, method: method}
+	if len(params) > 0 {
+		k.params = &params[0]
 	}
-	return
+	return k
 }
 
-// Unbox5 extracts five return values from a slice of interfaces.
-func Unbox5[R1, R2, R3, R4, R5 any](ret []interface{}) (r1 R1, r2 R2, r3 R3, r4 R4, r5 R5) {
-	if len(ret) == 5 {
-		r1, _ = ret[0].(R1)
-		r2, _ = ret[1].(R2)
-		r3, _ = ret[2].(R3)
-		r4, _ = ret[3].(R4)
-		r5, _ = ret[4].(R5)
-	} else {
-		log.Printf("Warning: unexpected number of return values: %d", len(ret))
+// record appends a call to the call history, if spied the call is kept
+// waiting for Spy to complete it, see recordSpied. Nothing is kept if the
+// history is disabled, see SetHistoryLimit.
+func (r *Manager) record(c Call, spied bool) *Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := &c
+	if r.history == 0 {
+		return p
+	}
+	r.calls = append(r.calls, p)
+	r.trimHistory()
+	if spied {
+		if r.pending == nil {
+			r.pending = make(map[pendingKey][]*Call)
+		}
+		k := newPendingKey(c.Type, c.Method, c.Params)
+		r.pending[k] = append(r.pending[k], p)
+	}
+	return p
+}
+
+// recordSpied completes the call waiting for Spy that was recorded by Invoke
+// with the same slice of parameters, with the outcome of a spy, or appends
+// the call to the call history if there is none.
+func (r *Manager) recordSpied(c Call) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := newPendingKey(c.Type, c.Method, c.Params)
+	if q := r.pending[k]; len(q) > 0 {
+		p := q[0]
+		if len(q) == 1 {
+			delete(r.pending, k)
+		} else {
+			r.pending[k] = q[1:]
+		}
+		p.Results, p.Invoker, p.Matched, p.Spied = c.Results, c.Invoker, c.Matched, c.Spied
+		return
+	}
+	if r.history != 0 {
+		r.calls = append(r.calls, &c)
+		r.trimHistory()
+	}
+}
+
+// invoke finds a matching Invoker and calls it based on the mocking mode.
+func (r *Manager) invoke(typ reflect.Type, method string, params []interface{}) ([]interface{}, Invoker, bool, *injectedPanic) {
+	stop := r.stopWhenExceeded()
+	for _, f := range r.lookup(typ, method) {
+		if b, ok := f.(Mocker); ok && !b.getBase().available(stop) {
+			overflow(f, params, stop)
+			continue
+		}
+		if ret, ok, p := call(f, params, stop); ok {
+			return ret, f, true, p
+		}
+	}
+	return nil, nil, false, nil
+}
+
+// overflow records the call as an overflow of the mocker of f, if the mocker
+// stopped matching because it reached its maximum number of calls, and its
+// condition accepts the call. Only the mockers in WhenReturn mode have a
+// condition to check.
+func overflow(f Invoker, params []interface{}, stop bool) {
+	a, ok := f.(acceptor)
+	if !ok || !f.(Mocker).getBase().capped(stop) || f.Mode() != ModeWhenReturn {
+		return
+	}
+	if a.accepts(params) {
+		f.(Mocker).getBase().overflowed(stop)
+	}
+}
+
+// call calls the Invoker based on its mocking mode, and counts the call if
+// it's handled. A panic injected by Panic is recovered and returned, so that
+// the call can be recorded first.
+func call(f Invoker, params []interface{}, stop bool) (ret []interface{}, ok bool, p *injectedPanic) {
+	b, _ := f.(Mocker)
+	reserved := false
+	defer func() {
+		v := recover()
+		i, injected := v.(injectedPanic)
+		if injected {
+			ok, p = true, &i
+		}
+		if reserved {
+			b.getBase().settle(ok)
+		}
+		if v != nil && !injected {
+			panic(v)
+		}
+	}()
+	switch f.Mode() {
+	case ModeHandle:
+		// the call is claimed before Handle, so that concurrent calls can't
+		// exceed the uses of the mocker, and given back if it's not handled
+		if b != nil {
+			if reserved = b.getBase().reserve(stop); !reserved {
+				return
+			}
+		}
+		ret, ok = f.Handle(params)
+	case ModeWhenReturn:
+		// the call is claimed before Return, so that concurrent calls can't
+		// exceed the uses of the mocker, nor its sequence of returns
+		if !f.When(params) {
+			break
+		}
+		if b == nil {
+			ok, ret = true, f.Return(params)
+			break
+		}
+		var i int
+		if i, ok = b.getBase().claim(stop); ok {
+			ret = f.(returner).returnAt(params, i)
+		}
+	default: // for linter
 	}
 	return
 }
+
+// spied reports whether any spy is registered for the given type and method.
+func (r *Manager) spied(typ reflect.Type, method string) bool {
+	for _, f := range r.lookup(typ, method) {
+		if f.Mode() == ModeSpy {
+			return true
+		}
+	}
+	return false
+}
+
+// spy finds a matching spy and lets it observe the results of the real implementation.
+func (r *Manager) spy(typ reflect.Type, method string, params []interface{}, results []interface{}) ([]interface{}, Invoker, bool) {
+	stop := r.stopWhenExceeded()
+	for _, f := range r.lookup(typ, method) {
+		s, ok := f.(Spier)
+		if !ok || f.Mode() != ModeSpy {
+			continue
+		}
+		// the call is claimed before Spy, which decides whether the spy
+		// applies, as in call
+		b, _ := f.(Mocker)
+		if b != nil && !b.getBase().reserve(stop) {
+			continue
+		}
+		ret, ok := s.Spy(params, results)
+		if b != nil {
+			b.getBase().settle(ok)
+		}
+		if ok {
+			return ret, f, true
+		}
+	}
+	return results, nil, false
+}
+
+// Verify reports every mocker whose call-count expectation is not met, and
+// every call made out of the order required by a Sequence that one of its
+// mockers joined, even if the mocker was removed since. A Sequence that
+// mockers of an ancestor Manager joined too is left to the ancestor, so that
+// its violations aren't reported twice.
+func (r *Manager) Verify(t testing.TB) {
+	t.Helper()
+	r.mu.Lock()
+	mockers := make(map[mockerKey][]Invoker, len(r.mockers))
+	keys := make([]mockerKey, 0, len(r.mockers))
+	for k, v := range r.mockers {
+		mockers[k] = invokers(v)
+		keys = append(keys, k)
+	}
+	seqs := r.seqs
+	r.mu.Unlock()
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].typ != keys[j].typ {
+			return keys[i].typ.String() < keys[j].typ.String()
+		}
+		return keys[i].method < keys[j].method
+	})
+	for _, k := range keys {
+		for _, f := range mockers[k] {
+			b, ok := f.(Mocker)
+			if !ok {
+				continue
+			}
+			if s := b.getBase().unmet(); s != "" {
+				t.Errorf("gomock: %s", s)
+			}
+		}
+	}
+	for _, seq := range seqs {
+		if r.parent.tracks(seq) {
+			continue
+		}
+		for _, s := range seq.getViolations() {
+			t.Errorf("gomock: %s", s)
+		}
+	}
+}
+
+// addSequence adds a sequence joined by one of the mockers, see Verify.
+func (r *Manager) addSequence(s *Sequence) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !slices.Contains(r.seqs, s) {
+		r.seqs = append(r.seqs, s)
+	}
+}
+
+// tracks reports whether a mocker of the Manager or of one of its ancestors
+// joined the sequence.
+func (r *Manager) tracks(s *Sequence) bool {
+	for ; r != nil; r = r.parent {
+		r.mu.Lock()
+		ok := slices.Contains(r.seqs, s)
+		r.mu.Unlock()
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reportUnmatched reports every call to a mocked method that no mocker
+// matched, except those already reported by InvokeMock.
+func (r *Manager) reportUnmatched(t testing.TB) {
+	t.Helper()
+	for _, c := range r.AllCalls() {
+		if c.Matched || c.Spied || c.reported || len(r.lookup(c.Type, c.Method)) == 0 {
+			continue
+		}
+		t.Error(r.describeUnmatched(c))
+	}
+}
+
+// reportNow reports an unmatched call at once through the test bound to the
+// Manager, or panics if there is none, see InvokeMock.
+func (r *Manager) reportNow(c *Call) {
+	r.mu.Lock()
+	c.reported = true
+	r.mu.Unlock()
+	if r.t == nil {
+		panic(fmt.Sprintf("gomock: %s.%s: no mock code matched", c.Type, c.Method))
+	}
+	r.t.Helper()
+	r.t.Error(r.describeUnmatched(*c))
+}
+
+// describeUnmatched describes a call that no mocker matched, with the
+// reasons why the mockers having matchers rejected it.
+func (r *Manager) describeUnmatched(c Call) string {
+	var candidates []string
+	for _, f := range r.lookup(c.Type, c.Method) {
+		b, ok := f.(Mocker)
+		if !ok || len(b.getBase().matchers()) == 0 {
+			continue
+		}
+		s := b.getBase().String()
+		if reason := b.getBase().explain(c.Params); reason != "" {
+			s += " [" + reason + "]"
+		}
+		candidates = append(candidates, s)
+	}
+	if len(candidates) == 0 {
+		return fmt.Sprintf("gomock: %s.%s: no mock matched the call with params %v", c.Type, c.Method, c.Params)
+	}
+	return fmt.Sprintf("gomock: %s.%s: no mock matched the call with params %v, candidates: %s",
+		c.Type, c.Method, c.Params, strings.Join(candidates, "; "))
+}
@@ -0,0 +1,589 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/gomock/match"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+// recorder is a testing.TB that records the reported errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Context() context.Context {
+	return context.Background()
+}
+
+func (r *recorder) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+// finish runs the registered cleanup functions as the end of a test does.
+func (r *recorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCalls(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+
+	MockGet(r).
+		When(func(ctx context.Context, req *Request, trace *Trace) bool {
+			return req.Token == "1:abc"
+		}).
+		Return(func() (resp *Response, err error) {
+			return &Response{Message: "1:abc"}, nil
+		})
+
+	req1 := &Request{Token: "1:abc"}
+	_, _ = c.Get(ctx, req1, &Trace{})
+	req2 := &Request{Token: "2:def"}
+	_, _ = c.Get(ctx, req2, &Trace{})
+	_, _, _ = c.GetWithHeader(ctx, req1, &Trace{})
+
+	calls := r.Calls(clientType, "Get")
+	assert.Equal(t, len(calls), 2)
+	assert.Equal(t, calls[0].Matched, true)
+	assert.Equal(t, calls[0].Params[1], req1)
+	assert.Equal(t, calls[0].Results[0].(*Response).Message, "1:abc")
+	assert.Equal(t, calls[0].Invoker, r.GetMockers(clientType, "Get")[0])
+	assert.Equal(t, calls[1].Matched, false)
+	assert.Equal(t, calls[1].Params[1], req2)
+	assert.Nil(t, calls[1].Results)
+	assert.Nil(t, calls[1].Invoker)
+
+	all := r.AllCalls()
+	assert.Equal(t, len(all), 3)
+	assert.Equal(t, all[2].Method, "GetWithHeader")
+	assert.Equal(t, all[2].Matched, false)
+}
+
+func TestMockerCalls(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+
+	m1 := MockGet(r).
+		When(func(ctx context.Context, req *Request, trace *Trace) bool {
+			return req.Token == "1:abc"
+		})
+	m1.Return(func() (resp *Response, err error) {
+		return &Response{Message: "1:abc"}, nil
+	})
+
+	m2 := MockGet(r)
+	m2.Handle(func(ctx context.Context, req *Request, trace *Trace) (resp *Response, err error, ok bool) {
+		return &Response{Message: "2:def"}, nil, req.Token == "2:def"
+	})
+
+	_, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{TraceId: "1"})
+	_, _ = c.Get(ctx, &Request{Token: "2:def"}, &Trace{TraceId: "2"})
+	_, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{TraceId: "3"})
+	_, _ = c.Get(ctx, &Request{Token: "3:ghi"}, &Trace{TraceId: "4"})
+
+	calls := m1.Calls()
+	assert.Equal(t, len(calls), 2)
+	_, req, trace := calls[0].Args()
+	assert.Equal(t, req.Token, "1:abc")
+	assert.Equal(t, trace.TraceId, "1")
+	assert.Equal(t, calls[1].P3.TraceId, "3")
+
+	calls = m2.Calls()
+	assert.Equal(t, len(calls), 1)
+	assert.Equal(t, calls[0].P2.Token, "2:def")
+}
+
+func TestExpectations(t *testing.T) {
+	var c Client
+
+	// Test case: expectations met
+	{
+		r, ctx := gomock.Init(context.Background())
+		MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return req.Token == "1:abc"
+			}).
+			Times(2).
+			Return(func() (resp *Response, err error) {
+				return &Response{Message: "1:abc"}, nil
+			})
+		MockGetWithHeader(r).Never()
+
+		_, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+		_, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+
+		// a call the mock doesn't accept falls through to the real implementation
+		resp, _ := c.Get(ctx, &Request{Token: "2:def"}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, len(rec.errors), 0)
+	}
+
+	// Test case: expectations not met
+	{
+		r, ctx := gomock.Init(context.Background())
+		MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			AtLeast(2).
+			Return(func() (resp *Response, err error) {
+				return &Response{Message: "1:abc"}, nil
+			})
+		MockGetWithHeader(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			Never().
+			Return(func() (resp *Response, _ map[string]string, err error) {
+				return &Response{Message: "3:123"}, nil, nil
+			})
+
+		MockGetWithHeader(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return req.Token == "1:abc"
+			}).
+			Times(1).
+			Return(func() (resp *Response, _ map[string]string, err error) {
+				return &Response{Message: "1:abc"}, nil, nil
+			})
+
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		resp, _, _ := c.GetWithHeader(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "9:yyy")
+
+		// the calls over the maximum fall through, but are reported
+		for i := 0; i < 2; i++ {
+			resp, _, _ = c.GetWithHeader(ctx, &Request{Token: "1:abc"}, &Trace{})
+		}
+		assert.Equal(t, resp.Message, "9:yyy")
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, rec.errors, []string{
+			"gomock: gomock_test.Client.Get: expected at least 2 call(s), got 1",
+			"gomock: gomock_test.Client.GetWithHeader: expected exactly 0 call(s), got 3",
+			"gomock: gomock_test.Client.GetWithHeader: expected exactly 1 call(s), got 2",
+		})
+	}
+
+	// Test case: ExceedFail
+	{
+		r, ctx := gomock.Init(context.Background())
+		r.SetExceedPolicy(gomock.ExceedFail)
+		MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			AtLeast(1).
+			AtMost(2).
+			Return(func() (resp *Response, err error) {
+				return &Response{Message: "1:abc"}, nil
+			})
+
+		for i := 0; i < 3; i++ {
+			resp, _ := c.Get(ctx, &Request{}, &Trace{})
+			assert.Equal(t, resp.Message, "1:abc")
+		}
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, rec.errors, []string{
+			"gomock: gomock_test.Client.Get: expected between 1 and 2 call(s), got 3",
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	var c Client
+
+	// Test case: everything is fine
+	{
+		r, ctx := gomock.New(t)
+		MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			Times(1).
+			Return(func() (resp *Response, err error) {
+				return &Response{Message: "1:abc"}, nil
+			})
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "1:abc")
+	}
+
+	// Test case: unmet expectations and unmatched calls
+	{
+		var rec recorder
+		r, ctx := gomock.New(&rec)
+		MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return req.Token == "1:abc"
+			}).
+			Times(1).
+			Return(func() (resp *Response, err error) {
+				return &Response{Message: "1:abc"}, nil
+			})
+
+		resp, _ := c.Get(ctx, &Request{Token: "2:def"}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+
+		// calls to methods without any mocker are not reported
+		resp, _, _ = c.GetWithHeader(ctx, &Request{Token: "2:def"}, &Trace{})
+		assert.Equal(t, resp.Message, "9:yyy")
+
+		assert.Equal(t, len(rec.errors), 0)
+		rec.finish()
+		assert.Equal(t, len(rec.errors), 2)
+		assert.Equal(t, rec.errors[0], "gomock: gomock_test.Client.Get: expected exactly 1 call(s), got 0")
+		assert.Equal(t, strings.HasPrefix(rec.errors[1], "gomock: gomock_test.Client.Get: no mock matched the call"), true)
+
+		// the Manager is unusable after the test ended
+		resp, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+		assert.Panic(t, func() {
+			MockGet(r)
+		}, "Manager used after the test ended")
+	}
+}
+
+func TestWhenArgs(t *testing.T) {
+	var c Client
+	var rec recorder
+	r, ctx := gomock.New(&rec)
+
+	MockGet(r).
+		WhenArgs(
+			match.Any[context.Context](),
+			match.Func("Token(1:abc)", func(req *Request) bool {
+				return req.Token == "1:abc"
+			}),
+			match.Not(match.Nil[*Trace]()),
+		).
+		Return(func() (resp *Response, err error) {
+			return &Response{Message: "1:abc"}, nil
+		})
+
+	resp, _ := c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+	assert.Equal(t, resp.Message, "1:abc")
+
+	resp, _ = c.Get(ctx, &Request{Token: "1:abc"}, nil)
+	assert.Equal(t, resp.Message, "9:xxx")
+
+	rec.finish()
+	assert.Equal(t, len(rec.errors), 1)
+	assert.Equal(t, strings.HasSuffix(rec.errors[0],
+		"candidates: gomock_test.Client.Get(Any(), Token(1:abc), Not(Nil())) "+
+			"[arg 3: (*gomock_test.Trace)(nil) does not match Not(Nil())]"), true)
+}
+
+func TestWhenArgsExplain(t *testing.T) {
+	var c Client
+	var rec recorder
+	r, ctx := gomock.New(&rec)
+
+	MockGet(r).
+		WhenArgs(
+			match.Any[context.Context](),
+			match.Fields(&Request{Token: "1:abc"}, "Token"),
+			match.Any[*Trace](),
+		).
+		Return(func() (resp *Response, err error) {
+			return &Response{Message: "1:abc"}, nil
+		})
+
+	resp, _ := c.Get(ctx, &Request{Token: "2:def"}, &Trace{})
+	assert.Equal(t, resp.Message, "9:xxx")
+
+	rec.finish()
+	assert.Equal(t, len(rec.errors), 1)
+	assert.Equal(t, strings.HasSuffix(rec.errors[0],
+		`candidates: gomock_test.Client.Get(Any(), Fields(Token), Any()) [arg 2: Token: got "2:def", want "1:abc"]`), true)
+}
+
+func TestRemoveAndReset(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	mc := NewMockClient(r)
+
+	// Test case: Remove
+	{
+		mockGetReturn(r, "1:abc")
+		m := mockGetReturn(r, "2:abc")
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 2)
+		m.Remove()
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 1)
+		m.Remove()
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 1)
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "1:abc")
+	}
+
+	// Test case: Remove of an Invoker added twice
+	{
+		m := mockGetReturn(r, "3:abc")
+		g := r.AddMocker(clientType, "Get", r.GetMockers(clientType, "Get")[0])
+		g.Remove()
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "1:abc")
+		m.Remove()
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 1)
+	}
+
+	// Test case: ResetMethod
+	{
+		mc.MockQuery()
+		mc.MockQueryWithHeader()
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		r.ResetMethod(clientType, "Get")
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 0)
+		assert.Equal(t, len(r.Calls(clientType, "Get")), 0)
+		assert.Equal(t, len(r.GetMockers(mockClientType, "Query")), 1)
+
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+		mockGetReturn(r, "2:def")
+		resp, _ = c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "2:def")
+	}
+
+	// Test case: ResetType
+	{
+		r.ResetType(mockClientType)
+		assert.Equal(t, len(r.GetMockers(mockClientType, "Query")), 0)
+		assert.Equal(t, len(r.GetMockers(mockClientType, "QueryWithHeader")), 0)
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 1)
+	}
+
+	// Test case: Reset
+	{
+		r.Reset()
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 0)
+		assert.Equal(t, len(r.AllCalls()), 0)
+	}
+}
+
+func TestChild(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	mockGetReturn(r, "parent")
+
+	// Test case: Manager.Child
+	{
+		child := r.Child()
+		mockGetReturn(child, "child").Once()
+		assert.Equal(t, len(child.GetMockers(clientType, "Get")), 1)
+
+		ret, ok := gomock.Invoke(child, clientType, "Get", ctx, &Request{}, &Trace{})
+		assert.Equal(t, ok, true)
+		assert.Equal(t, ret[0].(*Response).Message, "child")
+		ret, ok = gomock.Invoke(child, clientType, "Get", ctx, &Request{}, &Trace{})
+		assert.Equal(t, ok, true)
+		assert.Equal(t, ret[0].(*Response).Message, "parent")
+		assert.Equal(t, len(child.AllCalls()), 2)
+	}
+
+	// Test case: gomock.Child through InvokeContext
+	{
+		child, childCtx := gomock.Child(ctx)
+		mockGetReturn(child, "child").Once()
+
+		resp, _ := c.Get(childCtx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "child")
+		resp, _ = c.Get(childCtx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "parent")
+
+		// the parent is not affected by the child
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 1)
+		assert.Equal(t, len(r.AllCalls()), 0)
+	}
+
+	// Test case: gomock.Child without a Manager
+	{
+		child, childCtx := gomock.Child(context.Background())
+		mockGetReturn(child, "child").Once()
+		resp, _ := c.Get(childCtx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "child")
+		resp, _ = c.Get(childCtx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+	}
+
+	// Test case: the child of a Manager bound to a test is verified
+	{
+		var rec recorder
+		r, ctx := gomock.New(&rec)
+		child, childCtx := gomock.Child(ctx)
+		mockGetReturn(child, "child").Times(2)
+		_, _ = c.Get(childCtx, &Request{}, &Trace{})
+
+		rec.finish()
+		assert.Equal(t, rec.errors, []string{
+			"gomock: gomock_test.Client.Get: expected exactly 2 call(s), got 1",
+		})
+		resp, _ := c.Get(childCtx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+		assert.Equal(t, len(r.AllCalls()), 0)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	var c Client
+
+	// Test case: FirstWins
+	{
+		r, ctx := gomock.Init(context.Background())
+		mockGetReturn(r, "default")
+		mockGetReturn(r, "override")
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "default")
+	}
+
+	// Test case: LastWins
+	{
+		r, ctx := gomock.Init(context.Background())
+		r.SetPrecedence(gomock.LastWins)
+		mockGetReturn(r, "default")
+		mockGetReturn(r, "override")
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "override")
+	}
+
+	// Test case: Priority
+	{
+		r, ctx := gomock.Init(context.Background())
+		mockGetReturn(r, "default")
+		mockGetReturn(r, "override").Priority(1)
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "override")
+
+		r.SetPrecedence(gomock.LastWins)
+		mockGetReturn(r, "default")
+		mockGetReturn(r, "override").Priority(-1)
+		resp, _ = c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "override")
+		assert.Equal(t, len(r.GetMockers(clientType, "Get")), 4)
+	}
+}
+
+func TestStrictUnbox(t *testing.T) {
+	var c Client
+
+	// Test case: strict mode reports through the test
+	{
+		var rec recorder
+		r, ctx := gomock.New(&rec)
+		r.SetStrict(true)
+		gomock.NewMocker31[context.Context, *Request, *Trace, string](r, clientType, "Get").
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			Return(func() string {
+				return "1:abc"
+			})
+
+		resp, err := c.Get(ctx, &Request{}, &Trace{})
+		assert.Nil(t, resp)
+		assert.Nil(t, err)
+		assert.Equal(t, rec.errors, []string{
+			"gomock: gomock_test.Client.Get: unexpected number of return values: got 1, want 2",
+		})
+	}
+
+	// Test case: strict mode checks the results before they're unboxed
+	{
+		var rec recorder
+		r, ctx := gomock.New(&rec)
+		r.SetStrict(true)
+		gomock.NewMocker32[context.Context, *Request, *Trace, string, error](r, clientType, "Get").
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			Return(func() (string, error) {
+				return "1:abc", nil
+			})
+
+		ret, ok := gomock.Invoke(r, clientType, "Get", ctx, &Request{}, &Trace{})
+		assert.Equal(t, ok, true)
+		assert.Equal(t, append([]interface{}(nil), ret...), []interface{}{nil, nil})
+		assert.Equal(t, rec.errors, []string{
+			"gomock: gomock_test.Client.Get: unexpected type of return value 1: got string, want *gomock_test.Response",
+		})
+	}
+
+	// Test case: strict mode requires a bound test
+	{
+		r, _ := gomock.Init(context.Background())
+		assert.Panic(t, func() {
+			r.SetStrict(true)
+		}, "strict mode requires a Manager created by New")
+	}
+
+	// Test case: non-strict mode only logs
+	{
+		var rec recorder
+		r, ctx := gomock.New(&rec)
+		gomock.NewMocker31[context.Context, *Request, *Trace, string](r, clientType, "Get").
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			Return(func() string {
+				return "1:abc"
+			})
+
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Nil(t, resp)
+		assert.Equal(t, len(rec.errors), 0)
+	}
+
+	// Test case: UnboxNE variants
+	{
+		_, err := gomock.Unbox1E[error]([]interface{}{"1:abc"})
+		assert.Equal(t, err.Error(), "unexpected type of return value 1: got string, want error")
+		_, _, err = gomock.Unbox2E[*Response, error]([]interface{}{nil})
+		assert.Equal(t, err.Error(), "unexpected number of return values: got 1, want 2")
+		resp, respErr, err := gomock.Unbox2E[*Response, error]([]interface{}{&Response{Message: "2:def"}, nil})
+		assert.Nil(t, err)
+		assert.Nil(t, respErr)
+		assert.Equal(t, resp.Message, "2:def")
+		assert.Nil(t, gomock.Unbox0E(nil))
+	}
+}
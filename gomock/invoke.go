@@ -0,0 +1,112 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Invoke finds a matching Invoker and calls it based on the mocking mode.
+// A variadic parameter must be passed as a single slice, not spread, so
+// that mockers receive it as []T. Every call is recorded in the Manager's call history, matched or not.
+// A Manager created by New is ignored after its test has ended, and all
+// Managers are ignored when mocks are inactive, see SetActivationPolicy.
+func Invoke(r *Manager, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
+	if r == nil || r.isClosed() || !active() {
+		return nil, false
+	}
+	ret, ok, _ := r.invokeAndRecord(typ, method, params, r.spied(typ, method))
+	return ret, ok
+}
+
+// InvokeMock is like Invoke, for methods without a real implementation to
+// fall back to, such as those of the mocks generated by mockgen. A call no
+// mocker answers is reported at once through the test bound to the Manager,
+// and ok is false so that the method returns zero values. It panics instead
+// if there is no test to report to, as for a Manager created by Init.
+func InvokeMock(r *Manager, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
+	if r == nil || r.isClosed() || !active() {
+		panic(fmt.Sprintf("gomock: %s.%s: no mock code matched", typ, method))
+	}
+	ret, ok, c := r.invokeAndRecord(typ, method, params, false)
+	if !ok {
+		r.reportNow(c)
+	}
+	return ret, ok
+}
+
+// invokeAndRecord calls the matching Invoker and records the call, which is
+// kept waiting for Spy if unmatched and spied.
+func (r *Manager) invokeAndRecord(typ reflect.Type, method string, params []interface{}, spied bool) ([]interface{}, bool, *Call) {
+	ret, f, ok, p := r.invoke(typ, method, params)
+	c := r.record(Call{
+		Type:    typ,
+		Method:  method,
+		Params:  params,
+		Results: ret,
+		Invoker: f,
+		Matched: ok,
+	}, !ok && spied)
+	if p != nil {
+		panic(p.value)
+	}
+	if ok && r.isStrict() {
+		ret = r.checkResults(typ, method, ret)
+	}
+	return ret, ok, c
+}
+
+// InvokeContext is a convenience function that invokes a mock using context to retrieve the Manager.
+func InvokeContext(ctx context.Context, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
+	return Invoke(getManager(ctx), typ, method, params...)
+}
+
+// Spy reports the results of the real implementation of a method to the
+// spies registered for it, and returns the results to use, which a spy may
+// have tweaked. Instrumented methods call it after Invoke found no mock and
+// the real implementation has run, ok is false if no spy applied.
+// params must be the slice passed to Invoke, spread with params..., so that
+// the call recorded by Invoke is completed with the results of the real
+// implementation and marked as spied, instead of being recorded twice.
+func Spy(r *Manager, typ reflect.Type, method string, params []interface{}, results ...interface{}) ([]interface{}, bool) {
+	if r == nil || r.isClosed() || !active() || !r.spied(typ, method) {
+		return results, false
+	}
+	ret, f, ok := r.spy(typ, method, params, results)
+	r.recordSpied(Call{
+		Type:    typ,
+		Method:  method,
+		Params:  params,
+		Results: ret,
+		Invoker: f,
+		Matched: ok,
+		Spied:   true,
+	})
+	if ok && r.isStrict() {
+		ret = r.checkResults(typ, method, ret)
+	}
+	return ret, ok
+}
+
+// SpyContext is a convenience function that reports to spies using context to retrieve the Manager.
+func SpyContext(ctx context.Context, typ reflect.Type, method string, params []interface{}, results ...interface{}) ([]interface{}, bool) {
+	return Spy(getManager(ctx), typ, method, params, results...)
+}
@@ -0,0 +1,54 @@
+//go:build gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Invoke does nothing in binaries built with the gomock_disable tag, so
+// that instrumented methods cost nothing in production binaries.
+func Invoke(r *Manager, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
+	return nil, false
+}
+
+// InvokeMock panics in binaries built with the gomock_disable tag, as no
+// mock can answer the call.
+func InvokeMock(r *Manager, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
+	panic(fmt.Sprintf("gomock: %s.%s: no mock code matched", typ, method))
+}
+
+// InvokeContext does nothing in binaries built with the gomock_disable tag.
+func InvokeContext(ctx context.Context, typ reflect.Type, method string, params ...interface{}) ([]interface{}, bool) {
+	return nil, false
+}
+
+// Spy returns the results of the real implementation unchanged in binaries
+// built with the gomock_disable tag.
+func Spy(r *Manager, typ reflect.Type, method string, params []interface{}, results ...interface{}) ([]interface{}, bool) {
+	return results, false
+}
+
+// SpyContext returns the results of the real implementation unchanged in
+// binaries built with the gomock_disable tag.
+func SpyContext(ctx context.Context, typ reflect.Type, method string, params []interface{}, results ...interface{}) ([]interface{}, bool) {
+	return results, false
+}
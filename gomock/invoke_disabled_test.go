@@ -0,0 +1,78 @@
+//go:build gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+)
+
+var storeType = reflect.TypeFor[Store]()
+
+type Store struct{}
+
+// Item represents a stored item.
+type Item struct {
+	Id string
+}
+
+// Find retrieves an item, potentially using a mock implementation.
+func (s *Store) Find(ctx context.Context, id string, version int) (Item, error) {
+	if ret, ok := gomock.InvokeContext(ctx, storeType, "Find", ctx, id, version); ok {
+		return gomock.Unbox2[Item, error](ret)
+	}
+	return Item{Id: id}, nil
+}
+
+// MockFind registers a mock implementation for the Find method.
+func MockFind(r *gomock.Manager) *gomock.Mocker32[context.Context, string, int, Item, error] {
+	return gomock.NewMocker32[context.Context, string, int, Item, error](r, storeType, "Find")
+}
+
+var item Item
+
+func TestInvokeDisabled(t *testing.T) {
+	var s Store
+	r, ctx := gomock.Init(context.Background())
+	MockFind(r).Handle(func(ctx context.Context, id string, version int) (Item, error, bool) {
+		return Item{Id: "mock"}, nil, true
+	})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		item, _ = s.Find(ctx, "real", 1)
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations per call, want 0", allocs)
+	}
+	if item.Id != "real" {
+		t.Errorf("got item %q, want the real one", item.Id)
+	}
+}
+
+func BenchmarkInvokeDisabled(b *testing.B) {
+	var s Store
+	_, ctx := gomock.Init(context.Background())
+	b.ReportAllocs()
+	for b.Loop() {
+		item, _ = s.Find(ctx, "real", 1)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Option configures the comparison made by DeepEq and Fields.
+type Option func(*options)
+
+type options struct {
+	only             []string
+	ignoreFields     map[string]bool
+	ignoreTypes      map[reflect.Type]bool
+	ignoreUnexported bool
+	equateEmpty      bool
+}
+
+// Only restricts the comparison to the given fields, a field is named by its
+// path from the compared value, such as "Token" or "Header.TraceId".
+func Only(paths ...string) Option {
+	return func(o *options) {
+		o.only = append(o.only, paths...)
+	}
+}
+
+// IgnoreFields skips the given fields, named by their paths as in Only.
+func IgnoreFields(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.ignoreFields[p] = true
+		}
+	}
+}
+
+// IgnoreType skips the values of type T, such as time.Time.
+func IgnoreType[T any]() Option {
+	return func(o *options) {
+		o.ignoreTypes[reflect.TypeFor[T]()] = true
+	}
+}
+
+// IgnoreUnexported skips the unexported fields of structs.
+func IgnoreUnexported() Option {
+	return func(o *options) {
+		o.ignoreUnexported = true
+	}
+}
+
+// EquateEmpty treats nil and empty slices and maps as equal.
+func EquateEmpty() Option {
+	return func(o *options) {
+		o.equateEmpty = true
+	}
+}
+
+// deepMatcher is a Matcher that compares values field by field.
+type deepMatcher[T any] struct {
+	x    T
+	opts options
+	desc string
+}
+
+// DeepEq returns a Matcher that accepts values deeply equal to x, as
+// reflect.DeepEqual does, with the comparison configured by opts.
+func DeepEq[T any](x T, opts ...Option) Matcher[T] {
+	return newDeepMatcher(x, fmt.Sprintf("DeepEq(%#v)", x), opts)
+}
+
+// Fields returns a Matcher that accepts values whose given fields, named by
+// their paths as in Only, are deeply equal to those of x.
+func Fields[T any](x T, paths ...string) Matcher[T] {
+	return newDeepMatcher(x, fmt.Sprintf("Fields(%s)", strings.Join(paths, ", ")), []Option{Only(paths...)})
+}
+
+func newDeepMatcher[T any](x T, desc string, opts []Option) *deepMatcher[T] {
+	m := &deepMatcher[T]{x: x, desc: desc}
+	m.opts.ignoreFields = make(map[string]bool)
+	m.opts.ignoreTypes = make(map[reflect.Type]bool)
+	for _, opt := range opts {
+		opt(&m.opts)
+	}
+	return m
+}
+
+func (m *deepMatcher[T]) Match(v T) bool {
+	return len(m.diff(v)) == 0
+}
+
+func (m *deepMatcher[T]) String() string {
+	return m.desc
+}
+
+// Explain returns the field-level differences between v and the expected value.
+func (m *deepMatcher[T]) Explain(v T) string {
+	return strings.Join(m.diff(v), "; ")
+}
+
+func (m *deepMatcher[T]) diff(v T) []string {
+	d := differ{opts: &m.opts}
+	d.diff("", reflect.ValueOf(&v).Elem(), reflect.ValueOf(&m.x).Elem())
+	return d.diffs
+}
+
+// differ collects the differences between two values.
+type differ struct {
+	opts  *options
+	diffs []string
+	seen  map[visit]bool
+}
+
+// visit is a pair of pointers already compared, as in reflect.DeepEqual,
+// it stops the comparison of cyclic values.
+type visit struct {
+	got, want uintptr
+	typ       reflect.Type
+}
+
+// visited reports whether got and want, two non-nil pointers, maps or
+// slices of the same type, need no further comparison because they're
+// identical or already being compared, and marks them as visited.
+func (d *differ) visited(got, want reflect.Value) bool {
+	if got.Pointer() == want.Pointer() && (got.Kind() != reflect.Slice || got.Len() == want.Len()) {
+		return true
+	}
+	v := visit{got.Pointer(), want.Pointer(), got.Type()}
+	if d.seen[v] {
+		return true
+	}
+	if d.seen == nil {
+		d.seen = make(map[visit]bool)
+	}
+	d.seen[v] = true
+	return false
+}
+
+// report records a difference at the given path.
+func (d *differ) report(path string, format string, args ...interface{}) {
+	if path == "" {
+		path = "value"
+	}
+	d.diffs = append(d.diffs, path+": "+fmt.Sprintf(format, args...))
+}
+
+// included reports whether the field at the given path is to be compared.
+func (d *differ) included(path string) bool {
+	if d.opts.ignoreFields[path] {
+		return false
+	}
+	if len(d.opts.only) == 0 {
+		return true
+	}
+	for _, p := range d.opts.only {
+		if p == path || strings.HasPrefix(p, path+".") ||
+			strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty reports whether v is a nil or empty slice or map.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+// diff compares got and want, which have the same type.
+func (d *differ) diff(path string, got, want reflect.Value) {
+	if d.opts.ignoreTypes[got.Type()] {
+		return
+	}
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				d.report(path, "got %v, want %v", got, want)
+			}
+			return
+		}
+		if got.Kind() == reflect.Ptr && d.visited(got, want) {
+			return
+		}
+		got, want = got.Elem(), want.Elem()
+		if got.Type() != want.Type() {
+			d.report(path, "got type %s, want %s", got.Type(), want.Type())
+			return
+		}
+		d.diff(path, got, want)
+	case reflect.Struct:
+		t := got.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if d.opts.ignoreUnexported && !f.IsExported() {
+				continue
+			}
+			p := f.Name
+			if path != "" {
+				p = path + "." + f.Name
+			}
+			if d.included(p) {
+				d.diff(p, got.Field(i), want.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Map:
+		if d.opts.equateEmpty && isEmpty(got) && isEmpty(want) {
+			return
+		}
+		if got.IsNil() != want.IsNil() {
+			d.report(path, "got %#v, want %#v", got, want)
+			return
+		}
+		if got.Len() != want.Len() {
+			d.report(path, "got length %d, want %d", got.Len(), want.Len())
+			return
+		}
+		if got.IsNil() || d.visited(got, want) {
+			return
+		}
+		if got.Kind() == reflect.Slice {
+			d.diffElems(path, got, want)
+			return
+		}
+		iter := want.MapRange()
+		for iter.Next() {
+			p := fmt.Sprintf("%s[%#v]", path, iter.Key())
+			v := got.MapIndex(iter.Key())
+			if !v.IsValid() {
+				d.report(p, "missing")
+				continue
+			}
+			d.diff(p, v, iter.Value())
+		}
+	case reflect.Array:
+		d.diffElems(path, got, want)
+	case reflect.Func:
+		if !got.IsNil() || !want.IsNil() {
+			d.report(path, "func values are only equal if both are nil")
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if got.Pointer() != want.Pointer() {
+			d.report(path, "got %v, want %v", got, want)
+		}
+	default:
+		if !equalValue(got, want) {
+			d.report(path, "got %#v, want %#v", got, want)
+		}
+	}
+}
+
+// diffElems compares the elements of two slices or arrays of the same length.
+func (d *differ) diffElems(path string, got, want reflect.Value) {
+	for i := 0; i < got.Len(); i++ {
+		d.diff(fmt.Sprintf("%s[%d]", path, i), got.Index(i), want.Index(i))
+	}
+}
+
+// equalValue compares two values of a basic kind, it works for values
+// obtained through unexported fields as well.
+func equalValue(got, want reflect.Value) bool {
+	switch got.Kind() {
+	case reflect.Bool:
+		return got.Bool() == want.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return got.Int() == want.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return got.Uint() == want.Uint()
+	case reflect.Float32, reflect.Float64:
+		return got.Float() == want.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return got.Complex() == want.Complex()
+	case reflect.String:
+		return got.String() == want.String()
+	default:
+		return false
+	}
+}
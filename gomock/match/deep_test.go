@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package match_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lvan100/gomock/gomock/match"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+type Header struct {
+	TraceId string
+	Tags    map[string]string
+}
+
+type Request struct {
+	Header    *Header
+	Token     string
+	Items     []int
+	CreatedAt time.Time
+
+	sizeCache int
+}
+
+func newRequest() *Request {
+	return &Request{
+		Header:    &Header{TraceId: "t1", Tags: map[string]string{"a": "1"}},
+		Token:     "abc",
+		Items:     []int{1, 2},
+		CreatedAt: time.Unix(1, 0),
+		sizeCache: 1,
+	}
+}
+
+func TestDeepEq(t *testing.T) {
+
+	m := match.DeepEq(newRequest())
+	assert.Equal(t, m.Match(newRequest()), true)
+
+	req := newRequest()
+	req.Header.TraceId = "t2"
+	req.Header.Tags["a"] = "2"
+	req.Items[1] = 3
+	req.sizeCache = 2
+	assert.Equal(t, m.Match(req), false)
+	assert.Equal(t, match.Explain(m, req), `Header.TraceId: got "t2", want "t1"; `+
+		`Header.Tags["a"]: got "2", want "1"; Items[1]: got 3, want 2; sizeCache: got 2, want 1`)
+
+	req = newRequest()
+	req.CreatedAt = time.Now()
+	req.sizeCache = 2
+	m = match.DeepEq(newRequest(), match.IgnoreType[time.Time](), match.IgnoreUnexported())
+	assert.Equal(t, m.Match(req), true)
+
+	req = newRequest()
+	req.Items = nil
+	req.Header.Tags = map[string]string{}
+	m = match.DeepEq(newRequest())
+	assert.Equal(t, match.Explain(m, req), `Header.Tags: got length 0, want 1; Items: got []int(nil), want []int{1, 2}`)
+
+	req = newRequest()
+	req.Items = nil
+	expect := newRequest()
+	expect.Items = []int{}
+	assert.Equal(t, match.DeepEq(expect).Match(req), false)
+	assert.Equal(t, match.DeepEq(expect, match.EquateEmpty()).Match(req), true)
+
+	req = newRequest()
+	req.Header = nil
+	assert.Equal(t, match.Explain(match.DeepEq(newRequest()), req), "Header: got <nil>, want &{t1 map[a:1]}")
+	assert.Equal(t, match.DeepEq(newRequest(), match.IgnoreFields("Header")).Match(req), true)
+}
+
+func TestFields(t *testing.T) {
+
+	req := newRequest()
+	req.Token = "xyz"
+	req.Items = nil
+	req.Header.Tags = nil
+
+	m := match.Fields(newRequest(), "Header.TraceId", "CreatedAt")
+	assert.Equal(t, m.String(), "Fields(Header.TraceId, CreatedAt)")
+	assert.Equal(t, m.Match(req), true)
+
+	req.Header.TraceId = "t2"
+	assert.Equal(t, m.Match(req), false)
+	assert.Equal(t, match.Explain(m, req), `Header.TraceId: got "t2", want "t1"`)
+
+	m = match.Fields(newRequest(), "Header")
+	assert.Equal(t, match.Explain(m, req), `Header.TraceId: got "t2", want "t1"; `+
+		`Header.Tags: got map[string]string(nil), want map[string]string{"a":"1"}`)
+}
+
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+func TestDeepEqCyclic(t *testing.T) {
+
+	newCycle := func(values ...int) *Node {
+		head := &Node{Value: values[0]}
+		n := head
+		for _, v := range values[1:] {
+			n.Next = &Node{Value: v}
+			n = n.Next
+		}
+		n.Next = head
+		return head
+	}
+
+	m := match.DeepEq(newCycle(1, 2))
+	assert.Equal(t, m.Match(newCycle(1, 2)), true)
+	assert.Equal(t, match.Explain(m, newCycle(1, 3)), "Next.Value: got 3, want 2")
+
+	a := &Node{Value: 1}
+	a.Next = a
+	assert.Equal(t, match.DeepEq(a).Match(a), true)
+}
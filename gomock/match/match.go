@@ -0,0 +1,223 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package match provides composable typed matchers for mock parameters.
+package match
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Matcher decides whether a value of type T is acceptable.
+type Matcher[T any] interface {
+	// Match reports whether v is acceptable
+	Match(v T) bool
+	// String describes the acceptable values
+	String() string
+}
+
+// matcher is a Matcher made of a function and a description.
+type matcher[T any] struct {
+	fn   func(T) bool
+	desc string
+}
+
+func (m matcher[T]) Match(v T) bool {
+	return m.fn(v)
+}
+
+func (m matcher[T]) String() string {
+	return m.desc
+}
+
+// Func returns a Matcher that accepts the values for which fn returns true,
+// desc is used as the description of the Matcher.
+func Func[T any](desc string, fn func(T) bool) Matcher[T] {
+	return matcher[T]{fn: fn, desc: desc}
+}
+
+// Any returns a Matcher that accepts any value.
+func Any[T any]() Matcher[T] {
+	return Func("Any()", func(T) bool { return true })
+}
+
+// Eq returns a Matcher that accepts values deeply equal to x.
+func Eq[T any](x T) Matcher[T] {
+	return Func(fmt.Sprintf("Eq(%#v)", x), func(v T) bool {
+		return reflect.DeepEqual(v, x)
+	})
+}
+
+// isNil reports v is nil, but will not panic.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan,
+		reflect.Func,
+		reflect.Interface,
+		reflect.Map,
+		reflect.Ptr,
+		reflect.Slice,
+		reflect.UnsafePointer:
+		return v.IsNil()
+	default:
+		return !v.IsValid()
+	}
+}
+
+// Nil returns a Matcher that accepts nil values, including typed nils.
+func Nil[T any]() Matcher[T] {
+	return Func("Nil()", func(v T) bool {
+		return isNil(reflect.ValueOf(v))
+	})
+}
+
+// Not returns a Matcher that accepts the values m rejects.
+func Not[T any](m Matcher[T]) Matcher[T] {
+	return Func(fmt.Sprintf("Not(%s)", m), func(v T) bool {
+		return !m.Match(v)
+	})
+}
+
+// join returns the descriptions of the matchers separated by commas.
+func join[T any](ms []Matcher[T]) string {
+	ss := make([]string, len(ms))
+	for i, m := range ms {
+		ss[i] = m.String()
+	}
+	return strings.Join(ss, ", ")
+}
+
+// And returns a Matcher that accepts the values all of ms accept.
+func And[T any](ms ...Matcher[T]) Matcher[T] {
+	return Func(fmt.Sprintf("And(%s)", join(ms)), func(v T) bool {
+		for _, m := range ms {
+			if !m.Match(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Or returns a Matcher that accepts the values any of ms accepts.
+func Or[T any](ms ...Matcher[T]) Matcher[T] {
+	return Func(fmt.Sprintf("Or(%s)", join(ms)), func(v T) bool {
+		for _, m := range ms {
+			if m.Match(v) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Regexp returns a Matcher that accepts strings matching the expression expr.
+func Regexp(expr string) Matcher[string] {
+	r := regexp.MustCompile(expr)
+	return Func(fmt.Sprintf("Regexp(%q)", expr), r.MatchString)
+}
+
+// Len returns a Matcher that accepts arrays, channels, maps, slices and
+// strings of length n, including those held by an interface.
+func Len[T any](n int) Matcher[T] {
+	return Func(fmt.Sprintf("Len(%d)", n), func(v T) bool {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+			return rv.Len() == n
+		default:
+			return false
+		}
+	})
+}
+
+// Contains returns a Matcher that accepts slices containing an element
+// deeply equal to e.
+func Contains[S ~[]E, E any](e E) Matcher[S] {
+	return Func(fmt.Sprintf("Contains(%#v)", e), func(s S) bool {
+		for _, v := range s {
+			if reflect.DeepEqual(v, e) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// HasKey returns a Matcher that accepts maps containing the key k.
+func HasKey[M ~map[K]V, K comparable, V any](k K) Matcher[M] {
+	return Func(fmt.Sprintf("HasKey(%#v)", k), func(m M) bool {
+		_, ok := m[k]
+		return ok
+	})
+}
+
+// Type returns a Matcher that accepts values of type V, usually an interface,
+// whose dynamic type is T.
+func Type[T any, V any]() Matcher[V] {
+	return Func(fmt.Sprintf("Type(%s)", reflect.TypeFor[T]()), func(v V) bool {
+		_, ok := any(v).(T)
+		return ok
+	})
+}
+
+// Elems returns a Matcher that accepts slices, such as the variadic tail of
+// a method, whose elements are accepted one by one by ms, in order.
+func Elems[T any](ms ...Matcher[T]) Matcher[[]T] {
+	return Func(fmt.Sprintf("Elems(%s)", join(ms)), func(s []T) bool {
+		if len(s) != len(ms) {
+			return false
+		}
+		for i, m := range ms {
+			if !m.Match(s[i]) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Each returns a Matcher that accepts slices whose elements are all accepted by m.
+func Each[T any](m Matcher[T]) Matcher[[]T] {
+	return Func(fmt.Sprintf("Each(%s)", m), func(s []T) bool {
+		for _, v := range s {
+			if !m.Match(v) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Explainer is implemented by matchers that can tell why they reject a value.
+type Explainer[T any] interface {
+	// Explain returns why v is rejected
+	Explain(v T) string
+}
+
+// Explain returns why m rejects v, or an empty string if m accepts v.
+func Explain[T any](m Matcher[T], v T) string {
+	if m.Match(v) {
+		return ""
+	}
+	if e, ok := m.(Explainer[T]); ok {
+		return e.Explain(v)
+	}
+	return fmt.Sprintf("%#v does not match %s", v, m)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package match_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock/match"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+func TestMatchers(t *testing.T) {
+
+	testcases := []struct {
+		matcher match.Matcher[any]
+		value   any
+		expect  bool
+	}{
+		{match.Any[any](), nil, true},
+		{match.Eq[any](1), 1, true},
+		{match.Eq[any](1), 2, false},
+		{match.Eq[any]([]int{1}), []int{1}, true},
+		{match.Nil[any](), nil, true},
+		{match.Nil[any](), (*int)(nil), true},
+		{match.Nil[any](), 0, false},
+		{match.Not(match.Nil[any]()), 0, true},
+		{match.Len[any](2), []int{1, 2}, true},
+		{match.Len[any](2), "ab", true},
+		{match.Len[any](2), 2, false},
+		{match.And(match.Not(match.Nil[any]()), match.Len[any](1)), "a", true},
+		{match.And(match.Not(match.Nil[any]()), match.Len[any](1)), "ab", false},
+		{match.Or(match.Nil[any](), match.Len[any](1)), nil, true},
+		{match.Or(match.Nil[any](), match.Len[any](1)), "ab", false},
+		{match.Type[int, any](), 1, true},
+		{match.Type[int, any](), "1", false},
+		{match.Type[io.Reader, any](), &bytes.Buffer{}, true},
+	}
+
+	for i, c := range testcases {
+		if got := c.matcher.Match(c.value); got != c.expect {
+			t.Errorf("case %d: %s.Match(%v) got %v but expect %v", i, c.matcher, c.value, got, c.expect)
+		}
+	}
+
+	assert.Equal(t, match.Regexp("^a+$").Match("aaa"), true)
+	assert.Equal(t, match.Regexp("^a+$").Match("aab"), false)
+	assert.Equal(t, match.Contains[[]string]("b").Match([]string{"a", "b"}), true)
+	assert.Equal(t, match.Contains[[]string]("c").Match([]string{"a", "b"}), false)
+	assert.Equal(t, match.HasKey[map[string]int]("a").Match(map[string]int{"a": 0}), true)
+	assert.Equal(t, match.HasKey[map[string]int]("b").Match(map[string]int{"a": 0}), false)
+	assert.Equal(t, match.Elems(match.Eq[any](1), match.Any[any]()).Match([]any{1, "a"}), true)
+	assert.Equal(t, match.Elems(match.Eq[any](1), match.Any[any]()).Match([]any{2, "a"}), false)
+	assert.Equal(t, match.Elems(match.Eq[any](1)).Match([]any{1, "a"}), false)
+	assert.Equal(t, match.Elems[any]().Match(nil), true)
+	assert.Equal(t, match.Each(match.Regexp("^a")).Match([]string{"ab", "ac"}), true)
+	assert.Equal(t, match.Each(match.Regexp("^a")).Match([]string{"ab", "bc"}), false)
+	assert.Equal(t, match.Func("IsUpper()", func(s string) bool {
+		return strings.ToUpper(s) == s
+	}).Match("ABC"), true)
+}
+
+func TestDescriptions(t *testing.T) {
+	assert.Equal(t, match.Any[int]().String(), "Any()")
+	assert.Equal(t, match.Eq("a").String(), `Eq("a")`)
+	assert.Equal(t, match.Not(match.Nil[*int]()).String(), "Not(Nil())")
+	assert.Equal(t, match.Regexp("^a").String(), `Regexp("^a")`)
+	assert.Equal(t, match.Len[[]int](2).String(), "Len(2)")
+	assert.Equal(t, match.Contains[[]string]("b").String(), `Contains("b")`)
+	assert.Equal(t, match.HasKey[map[string]int]("a").String(), `HasKey("a")`)
+	assert.Equal(t, match.Type[*bytes.Buffer, io.Reader]().String(), "Type(*bytes.Buffer)")
+	assert.Equal(t, match.And(match.Eq(1), match.Eq(2)).String(), "And(Eq(1), Eq(2))")
+	assert.Equal(t, match.Or(match.Eq(1), match.Eq(2)).String(), "Or(Eq(1), Eq(2))")
+	assert.Equal(t, match.Elems(match.Eq(1), match.Any[int]()).String(), "Elems(Eq(1), Any())")
+	assert.Equal(t, match.Each(match.Eq(1)).String(), "Each(Eq(1))")
+	assert.Equal(t, match.Func("IsUpper()", func(string) bool { return true }).String(), "IsUpper()")
+}
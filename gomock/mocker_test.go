@@ -1,3 +1,5 @@
+//go:build !gomock_disable
+
 /*
  * Copyright 2025 The Go-Spring Authors.
  *
@@ -18,10 +20,13 @@ package gomock_test
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/gomock/match"
 	"github.com/lvan100/gomock/internal/assert"
 )
 
@@ -47,11 +52,17 @@ type Response struct {
 }
 
 // Get performs a request and retrieves a response, potentially using a mock implementation.
+// The real implementation can be observed by spies.
 func (c *Client) Get(ctx context.Context, req *Request, trace *Trace) (*Response, error) {
-	if ret, ok := gomock.InvokeContext(ctx, clientType, "Get", ctx, req, trace); ok {
+	params := []interface{}{ctx, req, trace}
+	if ret, ok := gomock.InvokeContext(ctx, clientType, "Get", params...); ok {
+		return gomock.Unbox2[*Response, error](ret)
+	}
+	resp, err := &Response{Message: "9:xxx"}, error(nil)
+	if ret, ok := gomock.SpyContext(ctx, clientType, "Get", params, resp, err); ok {
 		return gomock.Unbox2[*Response, error](ret)
 	}
-	return &Response{Message: "9:xxx"}, nil
+	return resp, err
 }
 
 // MockGet registers a mock implementation for the Get method.
@@ -59,6 +70,18 @@ func MockGet(r *gomock.Manager) *gomock.Mocker32[context.Context, *Request, *Tra
 	return gomock.NewMocker32[context.Context, *Request, *Trace, *Response, error](r, clientType, "Get")
 }
 
+// mockGetReturn registers a mock that answers every call of the Get method
+// with a response carrying the given message.
+func mockGetReturn(r *gomock.Manager, msg string) *gomock.Mocker32[context.Context, *Request, *Trace, *Response, error] {
+	m := MockGet(r).When(func(ctx context.Context, req *Request, trace *Trace) bool {
+		return true
+	})
+	m.Return(func() (*Response, error) {
+		return &Response{Message: msg}, nil
+	})
+	return m
+}
+
 // GetWithHeader performs a request and retrieves a response with additional headers, potentially using a mock implementation.
 func (c *Client) GetWithHeader(ctx context.Context, req *Request, trace *Trace) (*Response, map[string]string, error) {
 	if ret, ok := gomock.InvokeContext(ctx, clientType, "GetWithHeader", ctx, req, trace); ok {
@@ -296,3 +319,428 @@ func TestMockNoContext(t *testing.T) {
 		}, "mock error")
 	}
 }
+
+func TestReturnSequence(t *testing.T) {
+	// Test case: ExhaustRepeatLast
+	{
+		r, _ := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		mc.MockQuery().
+			When(func(req *Request, trace *Trace) bool {
+				return true
+			}).
+			OnExhausted(gomock.ExhaustRepeatLast).
+			ReturnSequence(
+				func() (*Response, error) {
+					return nil, errors.New("1st failure")
+				},
+				func() (*Response, error) {
+					return nil, errors.New("2nd failure")
+				},
+			).
+			Then(func() (*Response, error) {
+				return &Response{Message: "ok"}, nil
+			})
+		_, err := mc.Query(&Request{}, &Trace{})
+		assert.Equal(t, err.Error(), "1st failure")
+		_, err = mc.Query(&Request{}, &Trace{})
+		assert.Equal(t, err.Error(), "2nd failure")
+		for i := 0; i < 2; i++ {
+			resp, err := mc.Query(&Request{}, &Trace{})
+			assert.Nil(t, err)
+			assert.Equal(t, resp.Message, "ok")
+		}
+	}
+
+	// Test case: ExhaustStopMatching
+	{
+		r, _ := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		mc.MockQuery().
+			When(func(req *Request, trace *Trace) bool {
+				return true
+			}).
+			OnExhausted(gomock.ExhaustStopMatching).
+			ReturnSequence(
+				func() (*Response, error) {
+					return nil, errors.New("1st failure")
+				},
+				func() (*Response, error) {
+					return nil, errors.New("2nd failure")
+				},
+			).
+			Then(func() (*Response, error) {
+				return &Response{Message: "ok"}, nil
+			})
+		for i := 0; i < 3; i++ {
+			_, _ = mc.Query(&Request{}, &Trace{})
+		}
+		assert.Panic(t, func() {
+			_, _ = mc.Query(&Request{}, &Trace{})
+		}, "mock error")
+	}
+
+	// Test case: ExhaustFail
+	{
+		var rec recorder
+		r, _ := gomock.New(&rec)
+		mc := NewMockClient(r)
+		mc.MockQuery().
+			When(func(req *Request, trace *Trace) bool {
+				return true
+			}).
+			OnExhausted(gomock.ExhaustFail).
+			ReturnSequence(
+				func() (*Response, error) {
+					return nil, errors.New("1st failure")
+				},
+				func() (*Response, error) {
+					return nil, errors.New("2nd failure")
+				},
+			).
+			Then(func() (*Response, error) {
+				return &Response{Message: "ok"}, nil
+			})
+		for i := 0; i < 4; i++ {
+			_, _ = mc.Query(&Request{}, &Trace{})
+		}
+		assert.Equal(t, rec.errors, []string{
+			"gomock: gomock_test.MockClient.Query: called 4 times but only 3 returns were set",
+		})
+	}
+
+	// Test case: ExhaustFail without a test
+	{
+		r, _ := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		mc.MockQuery().
+			When(func(req *Request, trace *Trace) bool {
+				return true
+			}).
+			OnExhausted(gomock.ExhaustFail).
+			ReturnSequence(
+				func() (*Response, error) {
+					return nil, errors.New("1st failure")
+				},
+				func() (*Response, error) {
+					return nil, errors.New("2nd failure")
+				},
+			).
+			Then(func() (*Response, error) {
+				return &Response{Message: "ok"}, nil
+			})
+		for i := 0; i < 3; i++ {
+			_, _ = mc.Query(&Request{}, &Trace{})
+		}
+		assert.Panic(t, func() {
+			_, _ = mc.Query(&Request{}, &Trace{})
+		}, "called 4 times but only 3 returns were set")
+	}
+}
+
+func TestOnceAndUses(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	mockGetReturn(r, "empty").Once()
+	mockGetReturn(r, "populated").Uses(2)
+
+	for _, msg := range []string{"empty", "populated", "populated", "9:xxx"} {
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, msg)
+	}
+}
+
+func TestSpy(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+
+	// Test case: the spy observes the real implementation
+	spy := MockGet(r).Spy(nil)
+	resp, err := c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+	assert.Nil(t, err)
+	assert.Equal(t, resp.Message, "9:xxx")
+	assert.Equal(t, len(spy.Calls()), 1)
+	assert.Equal(t, spy.Calls()[0].P2.Token, "1:abc")
+
+	calls := r.Calls(clientType, "Get")
+	assert.Equal(t, len(calls), 1)
+	assert.Equal(t, calls[0].Spied, true)
+	assert.Equal(t, calls[0].Matched, true)
+	assert.Equal(t, calls[0].Results[0].(*Response).Message, "9:xxx")
+
+	// Test case: a spy with a condition tweaks the real results
+	MockGet(r).
+		When(func(ctx context.Context, req *Request, trace *Trace) bool {
+			return req.Token == "2:def"
+		}).
+		Spy(func(ctx context.Context, req *Request, trace *Trace, resp *Response, err error) (*Response, error) {
+			return &Response{Message: resp.Message + "!"}, err
+		}).
+		Priority(1)
+
+	resp, err = c.Get(ctx, &Request{Token: "2:def"}, &Trace{})
+	assert.Nil(t, err)
+	assert.Equal(t, resp.Message, "9:xxx!")
+	assert.Equal(t, len(spy.Calls()), 1)
+
+	// Test case: a mock still replaces the real implementation
+	MockGet(r).
+		When(func(ctx context.Context, req *Request, trace *Trace) bool {
+			return req.Token == "3:ghi"
+		}).
+		Return(func() (*Response, error) {
+			return &Response{Message: "3:ghi"}, nil
+		})
+
+	resp, err = c.Get(ctx, &Request{Token: "3:ghi"}, &Trace{})
+	assert.Nil(t, err)
+	assert.Equal(t, resp.Message, "3:ghi")
+	assert.Equal(t, len(spy.Calls()), 1)
+
+	calls = r.Calls(clientType, "Get")
+	assert.Equal(t, len(calls), 3)
+	assert.Equal(t, calls[2].Spied, false)
+	assert.Equal(t, calls[2].Matched, true)
+
+	// Test case: a call whose real implementation never reports to the spies
+	ret, ok := gomock.Invoke(r, clientType, "Get", ctx, &Request{Token: "4:jkl"}, &Trace{})
+	assert.Nil(t, ret)
+	assert.Equal(t, ok, false)
+
+	calls = r.Calls(clientType, "Get")
+	assert.Equal(t, len(calls), 4)
+	assert.Equal(t, calls[3].Spied, false)
+	assert.Equal(t, calls[3].Matched, false)
+	assert.Equal(t, calls[3].Params[1].(*Request).Token, "4:jkl")
+
+	// Test case: parameters that aren't comparable
+	{
+		var rec recorder
+		r, _ := gomock.New(&rec)
+		gomock.NewMocker11[func() string, string](r, clientType, "Visit").Spy(nil)
+
+		params := []interface{}{func() string { return "5:mno" }}
+		_, ok := gomock.Invoke(r, clientType, "Visit", params...)
+		assert.Equal(t, ok, false)
+		ret, ok := gomock.Spy(r, clientType, "Visit", params, "5:mno")
+		assert.Equal(t, ok, true)
+		assert.Equal(t, ret, []interface{}{"5:mno"})
+
+		calls := r.Calls(clientType, "Visit")
+		assert.Equal(t, len(calls), 1)
+		assert.Equal(t, calls[0].Spied, true)
+		rec.finish()
+		assert.Equal(t, len(rec.errors), 0)
+	}
+}
+
+func TestDelayAndPanic(t *testing.T) {
+	var c Client
+
+	// Test case: Delay
+	{
+		r, ctx := gomock.Init(context.Background())
+		MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return true
+			}).
+			Delay(10 * time.Millisecond).
+			Return(func() (*Response, error) {
+				return &Response{Message: "1:abc"}, nil
+			})
+
+		start := time.Now()
+		resp, err := c.Get(ctx, &Request{}, &Trace{})
+		assert.Nil(t, err)
+		assert.Equal(t, resp.Message, "1:abc")
+		assert.Equal(t, time.Since(start) >= 10*time.Millisecond, true)
+	}
+
+	// Test case: DelayFunc aborted by a cancelled context
+	{
+		r, ctx := gomock.Init(context.Background())
+		handled := false
+		m := MockGet(r).
+			DelayFunc(func(ctx context.Context, req *Request, trace *Trace) time.Duration {
+				return time.Hour
+			})
+		m.Handle(func(ctx context.Context, req *Request, trace *Trace) (*Response, error, bool) {
+			handled = true
+			return &Response{Message: "2:def"}, nil, true
+		})
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		resp, err := c.Get(ctx, &Request{}, &Trace{})
+		assert.Nil(t, resp)
+		assert.Equal(t, err, context.DeadlineExceeded)
+		assert.Equal(t, handled, true)
+		assert.Equal(t, len(m.Calls()), 1)
+	}
+
+	// Test case: Panic
+	{
+		r, ctx := gomock.Init(context.Background())
+		m := MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				return req.Token == "3:ghi"
+			}).
+			Panic("3:ghi")
+
+		assert.Panic(t, func() {
+			_, _ = c.Get(ctx, &Request{Token: "3:ghi"}, &Trace{})
+		}, "3:ghi")
+		assert.Equal(t, len(m.Calls()), 1)
+		assert.Equal(t, r.Calls(clientType, "Get")[0].Matched, true)
+	}
+
+	// Test case: the injections only apply to the calls the handler accepts
+	{
+		r, ctx := gomock.Init(context.Background())
+		m := MockGet(r).Delay(time.Hour).Panic("4:jkl")
+		m.Handle(func(ctx context.Context, req *Request, trace *Trace) (*Response, error, bool) {
+			return nil, nil, req.Token == "4:jkl"
+		})
+
+		start := time.Now()
+		resp, _ := c.Get(ctx, &Request{}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+		assert.Equal(t, time.Since(start) < time.Second, true)
+		assert.Equal(t, len(m.Calls()), 0)
+
+		m.Delay(0)
+		assert.Panic(t, func() {
+			_, _ = c.Get(ctx, &Request{Token: "4:jkl"}, &Trace{})
+		}, "4:jkl")
+		assert.Equal(t, len(m.Calls()), 1)
+		assert.Equal(t, r.Calls(clientType, "Get")[1].Matched, true)
+	}
+}
+
+var mockCodecType = reflect.TypeFor[MockCodec]()
+
+// MockCodec is a mock of a codec communicating through its parameters.
+type MockCodec struct {
+	r *gomock.Manager
+}
+
+// Decode mocks the Decode method by invoking a registered mock implementation.
+func (c *MockCodec) Decode(data []byte, v any) error {
+	if ret, ok := gomock.Invoke(c.r, mockCodecType, "Decode", data, v); ok {
+		return gomock.Unbox1[error](ret)
+	}
+	panic("mock error")
+}
+
+// MockDecode registers a mock implementation for the Decode method.
+func (c *MockCodec) MockDecode() *gomock.Mocker21[[]byte, any, error] {
+	return gomock.NewMocker21[[]byte, any, error](c.r, mockCodecType, "Decode")
+}
+
+// Scan mocks the Scan method by invoking a registered mock implementation.
+func (c *MockCodec) Scan(header map[string]string, buf []byte, dest ...any) error {
+	if ret, ok := gomock.Invoke(c.r, mockCodecType, "Scan", header, buf, dest); ok {
+		return gomock.Unbox1[error](ret)
+	}
+	panic("mock error")
+}
+
+// MockScan registers a mock implementation for the Scan method.
+func (c *MockCodec) MockScan() *gomock.Mocker31[map[string]string, []byte, []any, error] {
+	return gomock.NewMocker31[map[string]string, []byte, []any, error](c.r, mockCodecType, "Scan")
+}
+
+func TestSetArg(t *testing.T) {
+	r, _ := gomock.Init(context.Background())
+	c := &MockCodec{r}
+
+	// Test case: an interface parameter holding a pointer
+	c.MockDecode().
+		When(func(data []byte, v any) bool { return true }).
+		SetArg(1, Response{Message: "decoded"}).
+		Return(func() error { return nil })
+
+	var resp Response
+	assert.Nil(t, c.Decode(nil, &resp))
+	assert.Equal(t, resp.Message, "decoded")
+
+	// Test case: a handler that declines the call leaves it unchanged
+	r.Reset()
+	m := c.MockDecode().SetArg(1, Response{Message: "assigned"})
+	m.Handle(func(data []byte, v any) (error, bool) {
+		return nil, data != nil
+	})
+	c.MockDecode().
+		When(func(data []byte, v any) bool { return true }).
+		Return(func() error { return nil })
+	assert.Nil(t, c.Decode(nil, &resp))
+	assert.Equal(t, resp.Message, "decoded")
+	assert.Nil(t, c.Decode([]byte{}, &resp))
+	assert.Equal(t, resp.Message, "assigned")
+	r.Reset()
+
+	// Test case: a map, a byte slice and variadic pointers
+	c.MockScan().
+		When(func(header map[string]string, buf []byte, dest []any) bool { return true }).
+		SetArg(0, map[string]string{"k": "v"}).
+		SetArg(1, []byte("xyz")).
+		SetArg(2, []any{1, "one"}).
+		Return(func() error { return nil })
+
+	var (
+		n int
+		s string
+	)
+	header := map[string]string{"a": "b"}
+	buf := make([]byte, 2)
+	assert.Nil(t, c.Scan(header, buf, &n, &s))
+	assert.Equal(t, header, map[string]string{"a": "b", "k": "v"})
+	assert.Equal(t, string(buf), "xy")
+	assert.Equal(t, n, 1)
+	assert.Equal(t, s, "one")
+
+	// Test case: type mismatches at registration
+	assert.Panic(t, func() {
+		c.MockScan().SetArg(0, map[string]int{})
+	}, `gomock: gomock_test.MockCodec.Scan: SetArg\(0\): can't assign map\[string\]int to a parameter of type map\[string\]string`)
+	assert.Panic(t, func() {
+		c.MockScan().SetArg(3, nil)
+	}, `gomock: gomock_test.MockCodec.Scan: SetArg\(3\): the method has 3 parameters`)
+
+	// Test case: a type mismatch at call time
+	r.Reset()
+	c.MockDecode().
+		When(func(data []byte, v any) bool { return true }).
+		SetArg(1, "decoded").
+		Return(func() error { return nil })
+
+	assert.Panic(t, func() {
+		_ = c.Decode(nil, &resp)
+	}, `^gomock: gomock_test.MockCodec.Decode: SetArg\(1\): can't assign string to a parameter of type \*gomock_test.Response`)
+}
+
+func TestNilParams(t *testing.T) {
+	r, _ := gomock.Init(context.Background())
+	c := &MockCodec{r}
+
+	c.MockDecode().
+		WhenArgs(match.Nil[[]byte](), match.Nil[any]()).
+		Return(func() error { return errors.New("nil params") })
+
+	// Test case: nil is converted to the zero value
+	assert.Equal(t, c.Decode(nil, nil).Error(), "nil params")
+
+	// Test case: a genuine type mismatch
+	assert.Panic(t, func() {
+		_, _ = gomock.Invoke(r, mockCodecType, "Decode", "data", nil)
+	}, `^gomock: gomock_test.MockCodec.Decode: unexpected type of parameter 1: got string, want \[\]uint8$`)
+}
+
+func BenchmarkInvokeContext(b *testing.B) {
+	var c Client
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// mockFunc creates a function of type F whose calls are routed through
+// Invoke, with F as the mocked type and name as the method. It panics if F
+// is not a function with the given parameter and result types, and the
+// function panics if no mock matches a call.
+func mockFunc[F any](r *Manager, name string, paramTypes, resultTypes []reflect.Type) F {
+	ft := reflect.TypeFor[F]()
+	if !funcOf(ft, paramTypes, resultTypes) {
+		panic(fmt.Sprintf("gomock: MockFunc(%q): %s doesn't have parameters (%s) and results (%s)",
+			name, ft, typeNames(paramTypes), typeNames(resultTypes)))
+	}
+	fn := reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		params := make([]interface{}, len(args))
+		for i, a := range args {
+			params[i] = a.Interface()
+		}
+		ret, ok := Invoke(r, ft, name, params...)
+		if !ok {
+			panic(fmt.Sprintf("gomock: %s: no mock code matched", name))
+		}
+		results := make([]reflect.Value, ft.NumOut())
+		for i := range results {
+			results[i] = reflect.New(ft.Out(i)).Elem()
+			if i < len(ret) && ret[i] != nil {
+				results[i].Set(reflect.ValueOf(ret[i]))
+			}
+		}
+		return results
+	})
+	return fn.Interface().(F)
+}
+
+// funcOf reports whether t is a function with the given parameter and result
+// types, the variadic parameter of a function being a slice.
+func funcOf(t reflect.Type, paramTypes, resultTypes []reflect.Type) bool {
+	if t.Kind() != reflect.Func || t.NumIn() != len(paramTypes) || t.NumOut() != len(resultTypes) {
+		return false
+	}
+	for i, p := range paramTypes {
+		if t.In(i) != p {
+			return false
+		}
+	}
+	for i, r := range resultTypes {
+		if t.Out(i) != r {
+			return false
+		}
+	}
+	return true
+}
+
+// typeNames returns the names of the types separated by commas.
+func typeNames(types []reflect.Type) string {
+	ss := make([]string, len(types))
+	for i, t := range types {
+		ss[i] = t.String()
+	}
+	return strings.Join(ss, ", ")
+}
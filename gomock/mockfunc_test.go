@@ -0,0 +1,82 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+// Fetcher is a function-typed dependency.
+type Fetcher func(ctx context.Context, id string) (*Response, error)
+
+// Logger is a variadic function-typed dependency.
+type Logger func(format string, args ...any)
+
+func TestMockFunc(t *testing.T) {
+	r, ctx := gomock.Init(context.Background())
+
+	// Test case: When && Return
+	fetch, m := gomock.MockFunc22[Fetcher, context.Context, string, *Response, error](r, "fetch")
+	m.When(func(ctx context.Context, id string) bool {
+		return id == "1"
+	}).Return(func() (*Response, error) {
+		return &Response{Message: "1:abc"}, nil
+	})
+
+	resp, err := fetch(ctx, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, resp.Message, "1:abc")
+	assert.Equal(t, len(m.Calls()), 1)
+
+	// Test case: a nil result
+	fetch2, m2 := gomock.MockFunc22[Fetcher, context.Context, string, *Response, error](r, "fetch2")
+	m2.Handle(func(ctx context.Context, id string) (*Response, error, bool) {
+		return nil, errors.New("not found"), true
+	})
+	resp, err = fetch2(ctx, "2")
+	assert.Nil(t, resp)
+	assert.Equal(t, err.Error(), "not found")
+
+	// Test case: no mock matched
+	assert.Panic(t, func() {
+		_, _ = fetch(ctx, "3")
+	}, "gomock: fetch: no mock code matched")
+
+	// Test case: a variadic function
+	var logged []string
+	log, m3 := gomock.MockFunc20[Logger, string, []any](r, "log")
+	m3.When(func(format string, args []any) bool {
+		return true
+	}).Do(func(format string, args []any) {
+		logged = append(logged, format)
+	})
+	log("a=%d b=%d", 1, 2)
+	assert.Equal(t, logged, []string{"a=%d b=%d"})
+	assert.Equal(t, m3.Calls()[0].P2, []any{1, 2})
+
+	// Test case: the signature doesn't match
+	assert.Panic(t, func() {
+		gomock.MockFunc21[Fetcher, context.Context, string, error](r, "fetch")
+	}, `gomock: MockFunc\("fetch"\): gomock_test.Fetcher doesn't have parameters \(context.Context, string\) and results \(error\)`)
+}
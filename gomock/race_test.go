@@ -0,0 +1,224 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/gomock/match"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+func TestConcurrentUse(t *testing.T) {
+	const (
+		workers = 8
+		calls   = 50
+	)
+
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	mc := NewMockClient(r)
+
+	var captor gomock.Captor[*Request]
+	once := MockGet(r).
+		When(func(ctx context.Context, req *Request, trace *Trace) bool {
+			return true
+		}).
+		Capture(1, &captor).
+		Once()
+	once.Return(func() (*Response, error) {
+		return &Response{Message: "once"}, nil
+	})
+	fallback := MockGet(r).
+		When(func(ctx context.Context, req *Request, trace *Trace) bool {
+			return true
+		}).
+		Capture(1, &captor)
+	fallback.Return(func() (*Response, error) {
+		return &Response{Message: "fallback"}, nil
+	})
+	mc.MockQuery().Handle(func(req *Request, trace *Trace) (*Response, error, bool) {
+		return &Response{Message: "default"}, nil, true
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		// registers mockers while the others are invoked
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				token := fmt.Sprintf("%d:%d", i, j)
+				m := mc.MockQuery().
+					WhenArgs(match.Func("Token("+token+")", func(req *Request) bool {
+						return req.Token == token
+					}), match.Any[*Trace]()).
+					Times(1).
+					Priority(1)
+				m.Return(func() (*Response, error) {
+					return &Response{Message: token}, nil
+				})
+				_ = r.AllCalls()
+				_ = m.Calls()
+			}
+		}()
+		// invokes mockers while others are registered
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				_, _ = c.Get(ctx, &Request{}, &Trace{})
+				_, _ = mc.Query(&Request{Token: fmt.Sprintf("%d:%d", i, j)}, &Trace{})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(once.Calls()), 1)
+	assert.Equal(t, len(fallback.Calls()), workers*calls-1)
+	assert.Equal(t, captor.Len(), workers*calls)
+	assert.Equal(t, len(r.Calls(clientType, "Get")), workers*calls)
+	assert.Equal(t, len(r.Calls(mockClientType, "Query")), workers*calls)
+}
+
+func TestConcurrentReset(t *testing.T) {
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+	seq := gomock.NewSequence()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		// registers and removes mockers while the others are invoked
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				m := MockGetWithHeader(r).
+					When(func(ctx context.Context, req *Request, trace *Trace) bool {
+						return true
+					}).
+					InSequence(seq)
+				m.Return(func() (*Response, map[string]string, error) {
+					return &Response{Message: "mock"}, nil, nil
+				})
+				if j%10 == 0 {
+					r.ResetMethod(clientType, "GetWithHeader")
+				}
+			}
+		}()
+		// invokes mockers, or the real implementation, while others are registered
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				resp, _, _ := c.GetWithHeader(ctx, &Request{}, &Trace{})
+				if resp.Message != "mock" && resp.Message != "9:yyy" {
+					t.Errorf("unexpected message %q", resp.Message)
+				}
+				var rec recorder
+				r.Verify(&rec)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// getConcurrently calls Client.Get from n goroutines at once, and returns
+// the number of calls answered with the given message.
+func getConcurrently(ctx context.Context, n int, msg string) int {
+	var (
+		c      Client
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		served int
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if resp, _ := c.Get(ctx, &Request{}, &Trace{}); resp.Message == msg {
+				mu.Lock()
+				served++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return served
+}
+
+func TestConcurrentLimits(t *testing.T) {
+	const workers = 8
+
+	var c Client
+	r, ctx := gomock.Init(context.Background())
+
+	// Test case: a handler that declines gives the call back
+	{
+		m := MockGet(r).Once()
+		m.Handle(func(ctx context.Context, req *Request, trace *Trace) (*Response, error, bool) {
+			return &Response{Message: "once"}, nil, req.Token == "1:abc"
+		})
+		resp, _ := c.Get(ctx, &Request{Token: "2:def"}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+		resp, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+		assert.Equal(t, resp.Message, "once")
+		resp, _ = c.Get(ctx, &Request{Token: "1:abc"}, &Trace{})
+		assert.Equal(t, resp.Message, "9:xxx")
+		r.Reset()
+	}
+
+	// Test case: concurrent calls can't exceed the uses of the mocker
+	{
+		m := MockGet(r).Once()
+		m.Handle(func(ctx context.Context, req *Request, trace *Trace) (*Response, error, bool) {
+			time.Sleep(time.Millisecond)
+			return &Response{Message: "once"}, nil, true
+		})
+
+		assert.Equal(t, getConcurrently(ctx, workers, "once"), 1)
+		assert.Equal(t, len(m.Calls()), 1)
+		assert.Equal(t, len(r.Calls(clientType, "Get")), workers)
+	}
+
+	// Test case: concurrent calls can't exceed the sequence of returns
+	{
+		r.Reset()
+		m := MockGet(r).
+			When(func(ctx context.Context, req *Request, trace *Trace) bool {
+				time.Sleep(5 * time.Millisecond)
+				return true
+			}).
+			OnExhausted(gomock.ExhaustStopMatching).
+			ReturnSequence(func() (*Response, error) {
+				return &Response{Message: "once"}, nil
+			})
+
+		assert.Equal(t, getConcurrently(ctx, workers, "once"), 1)
+		assert.Equal(t, len(m.Calls()), 1)
+	}
+}
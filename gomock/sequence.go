@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Sequence is an ordered list of mockers that must be called in order,
+// possibly across different types and methods. A mocker may join several
+// sequences, and mockers that share no sequence may interleave freely, so
+// partial orders are expressed by using more than one Sequence.
+type Sequence struct {
+	mu         sync.Mutex
+	steps      []*base
+	actual     []*base
+	violations []string
+}
+
+// NewSequence creates a new empty Sequence.
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// InOrder creates a new Sequence made of the given mockers, in that order.
+func InOrder(mockers ...Mocker) *Sequence {
+	s := NewSequence()
+	for _, m := range mockers {
+		s.add(m.getBase())
+	}
+	return s
+}
+
+// add appends a mocker to the end of the sequence.
+func (s *Sequence) add(b *base) {
+	s.mu.Lock()
+	s.steps = append(s.steps, b)
+	s.mu.Unlock()
+	b.mu.Lock()
+	b.seqs = append(b.seqs, s)
+	b.mu.Unlock()
+	if b.r != nil {
+		b.r.addSequence(s)
+	}
+}
+
+// called checks that the call handled by b respects the order of the sequence,
+// a violation is recorded and later reported by Manager.Verify.
+func (s *Sequence) called(b *base) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actual = append(s.actual, b)
+	index := -1
+	for i, step := range s.steps {
+		if step == b {
+			index = i
+			break
+		}
+	}
+	for i, step := range s.steps {
+		count, satisfied := step.progress()
+		if (i < index && !satisfied) || (i > index && count > 0) {
+			s.violations = append(s.violations, fmt.Sprintf(
+				"out-of-order call to %s: expected sequence %s, actual %s",
+				b, formatSteps(s.steps), formatSteps(s.actual)))
+			return
+		}
+	}
+}
+
+// getViolations returns the violations of the order of the sequence.
+func (s *Sequence) getViolations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.violations...)
+}
+
+// formatSteps returns a text representation of the given steps.
+func formatSteps(steps []*base) string {
+	ss := make([]string, len(steps))
+	for i, b := range steps {
+		ss[i] = b.String()
+	}
+	return "[" + strings.Join(ss, ", ") + "]"
+}
@@ -0,0 +1,162 @@
+//go:build !gomock_disable
+
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/internal/assert"
+)
+
+// mockAll registers mockers that match every call of Client.Get,
+// MockClient.Query and MockClient.QueryWithHeader.
+func mockAll(r *gomock.Manager, mc *MockClient) (
+	*gomock.Mocker32[context.Context, *Request, *Trace, *Response, error],
+	*gomock.Mocker22[*Request, *Trace, *Response, error],
+	*gomock.Mocker23[*Request, *Trace, *Response, map[string]string, error],
+) {
+	m1 := MockGet(r).When(func(ctx context.Context, req *Request, trace *Trace) bool {
+		return true
+	})
+	m1.Return(func() (*Response, error) {
+		return &Response{}, nil
+	})
+	m2 := mc.MockQuery().When(func(req *Request, trace *Trace) bool {
+		return true
+	})
+	m2.Return(func() (*Response, error) {
+		return &Response{}, nil
+	})
+	m3 := mc.MockQueryWithHeader().When(func(req *Request, trace *Trace) bool {
+		return true
+	})
+	m3.Return(func() (*Response, map[string]string, error) {
+		return &Response{}, nil, nil
+	})
+	return m1, m2, m3
+}
+
+func TestInOrder(t *testing.T) {
+	var c Client
+
+	// Test case: calls in order
+	{
+		r, ctx := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		m1, m2, m3 := mockAll(r, mc)
+		gomock.InOrder(m1, m2.AtLeast(1), m3)
+
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		_, _ = mc.Query(&Request{}, &Trace{})
+		_, _ = mc.Query(&Request{}, &Trace{})
+		_, _, _ = mc.QueryWithHeader(&Request{}, &Trace{})
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, len(rec.errors), 0)
+	}
+
+	// Test case: calls out of order
+	{
+		r, ctx := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		m1, m2, m3 := mockAll(r, mc)
+		gomock.InOrder(m1, m2, m3)
+
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		_, _, _ = mc.QueryWithHeader(&Request{}, &Trace{})
+		_, _ = mc.Query(&Request{}, &Trace{})
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, rec.errors, []string{
+			"gomock: out-of-order call to gomock_test.MockClient.QueryWithHeader: " +
+				"expected sequence [gomock_test.Client.Get, gomock_test.MockClient.Query, gomock_test.MockClient.QueryWithHeader], " +
+				"actual [gomock_test.Client.Get, gomock_test.MockClient.QueryWithHeader]",
+			"gomock: out-of-order call to gomock_test.MockClient.Query: " +
+				"expected sequence [gomock_test.Client.Get, gomock_test.MockClient.Query, gomock_test.MockClient.QueryWithHeader], " +
+				"actual [gomock_test.Client.Get, gomock_test.MockClient.QueryWithHeader, gomock_test.MockClient.Query]",
+		})
+	}
+
+	// Test case: violations are reported after the mockers are reset
+	{
+		r, ctx := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		m1, m2, m3 := mockAll(r, mc)
+		gomock.InOrder(m1, m2, m3)
+
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		_, _, _ = mc.QueryWithHeader(&Request{}, &Trace{})
+		r.Reset()
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, len(rec.errors), 1)
+	}
+
+	// Test case: a sequence across a parent and a child is reported once
+	{
+		r, ctx := gomock.Init(context.Background())
+		m1, _, _ := mockAll(r, NewMockClient(r))
+		child := r.Child()
+		mc := NewMockClient(child)
+		_, m2, m3 := mockAll(child, mc)
+		gomock.InOrder(m1, m2, m3)
+
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		_, _, _ = mc.QueryWithHeader(&Request{}, &Trace{})
+
+		var rec recorder
+		child.Verify(&rec)
+		r.Verify(&rec)
+		assert.Equal(t, len(rec.errors), 1)
+	}
+}
+
+func TestPartialOrder(t *testing.T) {
+	var c Client
+
+	for _, queryFirst := range []bool{true, false} {
+		r, ctx := gomock.Init(context.Background())
+		mc := NewMockClient(r)
+		m1, m2, m3 := mockAll(r, mc)
+
+		// Get must come first, Query and QueryWithHeader may interleave.
+		s1, s2 := gomock.NewSequence(), gomock.NewSequence()
+		m1.InSequence(s1, s2)
+		m2.InSequence(s1)
+		m3.InSequence(s2)
+
+		_, _ = c.Get(ctx, &Request{}, &Trace{})
+		if queryFirst {
+			_, _ = mc.Query(&Request{}, &Trace{})
+			_, _, _ = mc.QueryWithHeader(&Request{}, &Trace{})
+		} else {
+			_, _, _ = mc.QueryWithHeader(&Request{}, &Trace{})
+			_, _ = mc.Query(&Request{}, &Trace{})
+		}
+
+		var rec recorder
+		r.Verify(&rec)
+		assert.Equal(t, len(rec.errors), 0)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// setter assigns a value through a parameter of the calls answered by a mocker.
+type setter struct {
+	index int
+	value reflect.Value
+}
+
+// setArg makes the mocker assign v through the i-th parameter before it
+// answers a call. The parameter must be:
+//   - a pointer, v is assigned to the pointed value, nil means the zero value;
+//   - a map, the entries of v are added to the map;
+//   - a slice of pointers or interfaces, such as the dest ...any of Scan,
+//     each element of v is assigned through the matching element;
+//   - any other slice, v is copied into the slice;
+//   - an interface, such as the v any of Decode, the above rules apply to
+//     the value passed at call time.
+//
+// It panics if v can't be assigned according to the type of the parameter,
+// the check is deferred to call time for interface parameters.
+func (b *base) setArg(i int, v interface{}) {
+	if i < 0 || i >= len(b.paramTypes) {
+		panic(fmt.Sprintf("gomock: %s.%s: SetArg(%d): the method has %d parameters", b.typ, b.method, i, len(b.paramTypes)))
+	}
+	value := reflect.ValueOf(v)
+	if err := checkArg(b.paramTypes[i], value); err != nil {
+		panic(fmt.Sprintf("gomock: %s.%s: SetArg(%d): %v", b.typ, b.method, i, err))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.setters = append(b.setters, setter{index: i, value: value})
+}
+
+// assignArgs assigns the values set by setArg through the parameters.
+func (b *base) assignArgs(params []interface{}) {
+	b.mu.Lock()
+	setters := b.setters
+	b.mu.Unlock()
+	for _, s := range setters {
+		if err := assignArg(reflect.ValueOf(params[s.index]), s.value); err != nil {
+			b.r.errorf("%s.%s: SetArg(%d): %v", b.typ, b.method, s.index, err)
+		}
+	}
+}
+
+// checkArg checks that v can be assigned through a parameter of type t.
+func checkArg(t reflect.Type, v reflect.Value) error {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil // checked at call time
+	case reflect.Pointer:
+		if !v.IsValid() || v.Type().AssignableTo(t.Elem()) {
+			return nil
+		}
+	case reflect.Map:
+		if v.IsValid() && v.Type().AssignableTo(t) {
+			return nil
+		}
+	case reflect.Slice:
+		if throughElems(t, v) {
+			for k := 0; k < v.Len(); k++ {
+				if err := checkArg(t.Elem(), v.Index(k)); err != nil {
+					return fmt.Errorf("element %d: %w", k, err)
+				}
+			}
+			return nil
+		}
+		if v.IsValid() && v.Type().AssignableTo(t) {
+			return nil
+		}
+	default:
+		return fmt.Errorf("a parameter of type %s can't be set", t)
+	}
+	return fmt.Errorf("can't assign %s to a parameter of type %s", typeName(v), t)
+}
+
+// assignArg assigns v through the parameter dst.
+func assignArg(dst reflect.Value, v reflect.Value) error {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if dst.Kind() == reflect.Interface {
+		dst = dst.Elem()
+	}
+	if !dst.IsValid() {
+		return fmt.Errorf("can't assign %s through a nil parameter", typeName(v))
+	}
+	if err := checkArg(dst.Type(), v); err != nil {
+		return err
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			return fmt.Errorf("can't assign through a nil %s", dst.Type())
+		}
+		if !v.IsValid() {
+			dst.Elem().SetZero()
+		} else {
+			dst.Elem().Set(v)
+		}
+	case reflect.Map:
+		if dst.IsNil() {
+			return fmt.Errorf("can't add entries to a nil %s", dst.Type())
+		}
+		for it := v.MapRange(); it.Next(); {
+			dst.SetMapIndex(it.Key(), it.Value())
+		}
+	case reflect.Slice:
+		if !throughElems(dst.Type(), v) {
+			reflect.Copy(dst, v)
+			break
+		}
+		for k := 0; k < v.Len() && k < dst.Len(); k++ {
+			if err := assignArg(dst.Index(k), v.Index(k)); err != nil {
+				return fmt.Errorf("element %d: %w", k, err)
+			}
+		}
+	default: // for linter
+	}
+	return nil
+}
+
+// throughElems reports whether v is assigned through the elements of a
+// slice of type t, rather than copied into the slice.
+func throughElems(t reflect.Type, v reflect.Value) bool {
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return false
+	}
+	switch t.Elem().Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer:
+		return !v.Type().AssignableTo(t)
+	default:
+		return false
+	}
+}
+
+// typeName returns the name of the type of v, or "nil" if v is invalid.
+func typeName(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 The Go-Spring Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gomock
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+)
+
+// resultTypes returns the result types of the method of typ, or of typ
+// itself for a function type, see MockFuncNM. ok is false if the method
+// can't be found through reflection, such as an unexported method.
+func resultTypes(typ reflect.Type, method string) (types []reflect.Type, ok bool) {
+	fn := typ
+	switch typ.Kind() {
+	case reflect.Func:
+	case reflect.Interface:
+		m, found := typ.MethodByName(method)
+		if !found {
+			return nil, false
+		}
+		fn = m.Type
+	default:
+		m, found := reflect.PointerTo(typ).MethodByName(method)
+		if !found {
+			return nil, false
+		}
+		fn = m.Type
+	}
+	for i := 0; i < fn.NumOut(); i++ {
+		types = append(types, fn.Out(i))
+	}
+	return types, true
+}
+
+// checkResults checks that the results match the given types, as UnboxN
+// does, a nil result matches any type.
+func checkResults(ret []interface{}, types []reflect.Type) error {
+	if err := checkCount(ret, len(types)); err != nil {
+		return err
+	}
+	for i, v := range ret {
+		if v != nil && !reflect.TypeOf(v).AssignableTo(types[i]) {
+			return fmt.Errorf("unexpected type of return value %d: got %T, want %s", i+1, v, types[i])
+		}
+	}
+	return nil
+}
+
+// reportUnbox logs a mismatch found by UnboxN.
+func reportUnbox(err error) {
+	log.Printf("Warning: %v", err)
+}
+
+// checkCount checks that there are n results.
+func checkCount(ret []interface{}, n int) error {
+	if len(ret) != n {
+		return fmt.Errorf("unexpected number of return values: got %d, want %d", len(ret), n)
+	}
+	return nil
+}
+
+// unboxAt extracts the i-th result as an R. A nil result is the zero value
+// of R. err is returned if it's not nil, so that the first mismatch is kept.
+func unboxAt[R any](ret []interface{}, i int, err error) (R, error) {
+	v, ok := ret[i].(R)
+	if !ok && ret[i] != nil && err == nil {
+		err = fmt.Errorf("unexpected type of return value %d: got %T, want %s", i+1, ret[i], reflect.TypeFor[R]())
+	}
+	return v, err
+}
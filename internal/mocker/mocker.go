@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"go/format"
 	"os"
@@ -27,38 +28,270 @@ import (
 	"text/template"
 )
 
+var callTmpl = template.Must(template.New("call").Parse(`
+/********************************* {{.callName}} ************************************/
+{{if .req}}
+// {{.callName}} records the parameters of a call handled by a Mocker{{.count}}N.
+type {{.callName}}[{{.req}} any] struct {
+	{{- range .fields}}
+	{{.}}
+	{{- end}}
+}
+
+// Args returns the parameters of the call.
+func (c {{.callName}}[{{.req}}]) Args() ({{.req}}) {
+	return {{.args}}
+}
+{{- else}}
+// {{.callName}} records a call handled by a Mocker0N, which has no parameters.
+type {{.callName}} struct{}
+{{- end}}
+`))
+
 var mockerTmpl = template.Must(template.New("mocker").Parse(`
 /******************************** {{.mockerName}} ***********************************/
 
-type {{.mockerName}}[{{.req}} any, {{.resp}} any] struct {
-	fnHandle func({{.req}}) ({{.resp}}, bool)
+type {{.mockerName}}{{.typeParams}} struct {
+	base
+	fnHandle {{.handleFunc}}
 	fnWhen   func({{.req}}) bool
+	fnSpy    {{.spyFunc}}
+{{- if .resp}}
 	fnReturn func() ({{.resp}})
+	returns  []func() ({{.resp}})
+{{- else}}
+	fnDo     func({{.req}})
+{{- end}}
+	calls    []{{.callType}}
 }
 
-// Handle sets a custom function to handle requests.
-func (m *{{.mockerName}}[{{.req}}, {{.resp}}]) Handle(fn func({{.req}}) ({{.resp}}, bool)) {
+// Handle sets a custom function to handle requests. fn decides whether the
+// mock answers a call, so the latency, parameter assignments and panic
+// injected into the mock only apply once fn has accepted the call, after it
+// has run. An aborted wait replaces the results of fn.
+func (m *{{.mocker}}) Handle(fn {{.handleFunc}}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.fnHandle = fn
 }
 
 // When sets a condition function that determines if the mock should apply.
-func (m *{{.mockerName}}[{{.req}}, {{.resp}}]) When(fn func({{.req}}) bool) *{{.mockerName}}[{{.req}}, {{.resp}}] {
+func (m *{{.mocker}}) When(fn func({{.req}}) bool) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.fnWhen = fn
 	return m
 }
+{{- if .req}}
+
+// WhenArgs sets matchers that the parameters must satisfy for the mock to apply.
+func (m *{{.mocker}}) WhenArgs({{.matchers}}) *{{.mocker}} {
+	args := []arg{ {{.matcherArgs}} }
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.args = args
+	m.fnWhen = func({{.values}}) bool {
+		return {{.matches}}
+	}
+	return m
+}
+
+// SetArg makes the mock assign value through the i-th parameter, counted
+// from 0, before answering a call. The parameter must be a pointer, a map,
+// a slice or an interface holding one of them, and the type of value is
+// checked against it here, or at call time for an interface.
+func (m *{{.mocker}}) SetArg(i int, value interface{}) *{{.mocker}} {
+	m.setArg(i, value)
+	return m
+}
+
+// Capture attaches a Captor to the i-th parameter, counted from 0, which
+// accumulates the values passed by the calls the mock answers. The type of
+// the parameter must be assignable to the type of the values of c.
+func (m *{{.mocker}}) Capture(i int, c Capturer) *{{.mocker}} {
+	m.addCaptor(i, c)
+	return m
+}
+{{- end}}
+{{- if .resp}}
 
 // Return sets a function that returns predefined values.
-func (m *{{.mockerName}}[{{.req}}, {{.resp}}]) Return(fn func() ({{.resp}})) {
+func (m *{{.mocker}}) Return(fn func() ({{.resp}})) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.fnReturn = fn
 }
 
+// ReturnSequence sets functions that return predefined values, the first
+// matching call uses the first function, the second call the second, and so on.
+func (m *{{.mocker}}) ReturnSequence(fns ...func() ({{.resp}})) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.returns = fns
+	m.nreturns = len(fns)
+	return m
+}
+
+// Then appends a function to the sequence of returns.
+func (m *{{.mocker}}) Then(fn func() ({{.resp}})) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.returns = append(m.returns, fn)
+	m.nreturns = len(m.returns)
+	return m
+}
+
+// OnExhausted sets what happens once the sequence of returns is exhausted,
+// the default is ExhaustRepeatLast.
+func (m *{{.mocker}}) OnExhausted(p ExhaustPolicy) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exhaust = p
+	return m
+}
+{{- else}}
+
+// Do sets a function that performs the side effects of the method,
+// it's optional as the method returns nothing.
+func (m *{{.mocker}}) Do(fn func({{.req}})) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fnDo = fn
+}
+{{- end}}
+
+// Delay makes the mock wait for d before answering a call. If the first
+// parameter is a context that gets cancelled, the wait is aborted and the
+// mock answers with zero values, and the context error as its error result.
+func (m *{{.mocker}}) Delay(d time.Duration) *{{.mocker}} {
+	return m.DelayFunc(func({{.req}}) time.Duration {
+		return d
+	})
+}
+
+// DelayFunc is like Delay, but computes the wait from the parameters.
+func (m *{{.mocker}}) DelayFunc(fn func({{.req}}) time.Duration) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delay = func(params []interface{}) time.Duration {
+		return fn({{.cvtParams}})
+	}
+	return m
+}
+
+// Panic makes the mock panic with v when it answers a call, after any delay.
+func (m *{{.mocker}}) Panic(v interface{}) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.panics = true
+	m.panicValue = v
+	return m
+}
+
+// Spy turns the mock into a spy, which observes the real implementation of
+// the method instead of replacing it. The optional fn is
+{{- if .resp}}
+// called with the parameters and the real results, and returns the results to use.
+{{- else}}
+// called with the parameters after the real implementation has run.
+{{- end}}
+// A spy applies to every call unless restricted by When or WhenArgs.
+func (m *{{.mocker}}) Spy(fn {{.spyFunc}}) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spy = true
+	m.fnSpy = fn
+	return m
+}
+
+// Calls returns the calls handled by this mocker, in the order they were made.
+func (m *{{.mocker}}) Calls() []{{.callType}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]{{.callType}}(nil), m.calls...)
+}
+
+// addCall records a call handled by this mocker.
+func (m *{{.mocker}}) addCall(c {{.callType}}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, c)
+}
+
+// Times expects the mock to be called exactly n times.
+func (m *{{.mocker}}) Times(n int) *{{.mocker}} {
+	m.setMin(n)
+	m.setMax(n)
+	return m
+}
+
+// AtLeast expects the mock to be called at least n times.
+func (m *{{.mocker}}) AtLeast(n int) *{{.mocker}} {
+	m.setMin(n)
+	return m
+}
+
+// AtMost expects the mock to be called at most n times.
+func (m *{{.mocker}}) AtMost(n int) *{{.mocker}} {
+	m.setMax(n)
+	return m
+}
+
+// Never expects the mock not to be called at all.
+func (m *{{.mocker}}) Never() *{{.mocker}} {
+	return m.Times(0)
+}
+
+// Once retires the mock after it has handled one call, letting the mocks
+// registered after it handle the following calls.
+func (m *{{.mocker}}) Once() *{{.mocker}} {
+	return m.Uses(1)
+}
+
+// Uses retires the mock after it has handled n calls, letting the mocks
+// registered after it handle the following calls.
+func (m *{{.mocker}}) Uses(n int) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uses = n
+	return m
+}
+
+// Priority sets the priority of the mock, mocks with a higher priority
+// are tried first whatever the precedence of the Manager, the default is 0.
+func (m *{{.mocker}}) Priority(n int) *{{.mocker}} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.priority = n
+	return m
+}
+
+// InSequence appends the mock to the end of the given sequences.
+func (m *{{.mocker}}) InSequence(seqs ...*Sequence) *{{.mocker}} {
+	for _, s := range seqs {
+		s.add(&m.base)
+	}
+	return m
+}
+
+// Remove removes the mock from the Manager, the following calls are handled
+// by the other mocks. It does nothing if the mock has already been removed.
+func (m *{{.mocker}}) Remove() {
+	m.reg.Remove()
+}
+
 // {{.invokerName}} is an Invoker implementation for {{.mockerName}}.
-type {{.invokerName}}[{{.req}} any, {{.resp}} any] struct {
-	*{{.mockerName}}[{{.req}}, {{.resp}}]
+type {{.invokerName}}{{.typeParams}} struct {
+	*{{.mocker}}
 }
 
-// Mode determines whether the mock operates in Handle mode or WhenReturn mode.
-func (m *{{.invokerName}}[{{.req}}, {{.resp}}]) Mode() Mode {
+// Mode determines whether the mock operates in Spy, Handle or WhenReturn mode.
+func (m *{{.invoker}}) Mode() Mode {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.spy {
+		return ModeSpy
+	}
 	if m.fnHandle != nil {
 		return ModeHandle
 	}
@@ -66,34 +299,202 @@ func (m *{{.invokerName}}[{{.req}}, {{.resp}}]) Mode() Mode {
 }
 
 // Handle executes the custom function if set.
-func (m *{{.invokerName}}[{{.req}}, {{.resp}}]) Handle(params []interface{}) ([]interface{}, bool) {
-	{{.respOnlyArg}}, ok := m.fnHandle({{.cvtParams}})
-	return []interface{}{ {{.respOnlyArg}}}, ok
+func (m *{{.invoker}}) Handle(params []interface{}) ([]interface{}, bool) {
+	m.mu.Lock()
+	fn := m.fnHandle
+	m.mu.Unlock()
+	{{.handleVars}} := fn({{.cvtParams}})
+	if !ok {
+		return nil, false
+	}
+	m.addCall({{.callType}}{ {{.cvtParams}}})
+	if err := m.inject(params); err != nil {
+		return {{.abortResults}}, true
+	}
+	return {{.boxedResults}}, true
 }
 
 // When checks if the condition function evaluates to true.
-func (m *{{.invokerName}}[{{.req}}, {{.resp}}]) When(params []interface{}) bool {
-	if m.fnWhen == nil {
+func (m *{{.invoker}}) When(params []interface{}) bool {
+	m.mu.Lock()
+	fn := m.fnWhen
+{{- if .resp}}
+	// a mock without returns may still be being set up by another goroutine
+	ready := m.fnReturn != nil || len(m.returns) > 0 || m.panics
+{{- end}}
+	m.mu.Unlock()
+{{- if .resp}}
+	if fn == nil || !ready {
+{{- else}}
+	if fn == nil {
+{{- end}}
 		return false
 	}
-	return m.fnWhen({{.cvtParams}})
+	return fn({{.cvtParams}})
+}
+
+// accepts reports whether the condition of the mock accepts the parameters,
+// whatever the state of the mock, a mock without condition accepts them all.
+func (m *{{.invoker}}) accepts(params []interface{}) bool {
+	m.mu.Lock()
+	fn := m.fnWhen
+	m.mu.Unlock()
+	return fn == nil || fn({{.cvtParams}})
 }
+{{- if .resp}}
 
 // Return provides predefined response and error values.
-func (m *{{.invokerName}}[{{.req}}, {{.resp}}]) Return(params []interface{}) []interface{} {
-	{{.respOnlyArg}} := m.fnReturn()
-	return []interface{}{ {{.respOnlyArg}}}
+func (m *{{.invoker}}) Return(params []interface{}) []interface{} {
+	return m.returnAt(params, m.nextReturn())
+}
+
+// returnAt provides the values of the i-th return of the sequence, counted
+// from 0, or the predefined values if there is no sequence.
+func (m *{{.invoker}}) returnAt(params []interface{}, i int) []interface{} {
+	m.addCall({{.callType}}{ {{.cvtParams}}})
+	if err := m.inject(params); err != nil {
+		return {{.abortResults}}
+	}
+	m.mu.Lock()
+	fn, returns := m.fnReturn, m.returns
+	m.mu.Unlock()
+	if len(returns) > 0 {
+		fn = returns[m.pickReturn(i, len(returns))]
+	}
+	{{.respOnlyArg}} := fn()
+	return {{.boxedResults}}
+}
+{{- else}}
+
+// Return performs the side effects if set, there is no value to return.
+func (m *{{.invoker}}) Return(params []interface{}) []interface{} {
+	return m.returnAt(params, 0)
+}
+
+// returnAt performs the side effects if set, there is no sequence of returns.
+func (m *{{.invoker}}) returnAt(params []interface{}, _ int) []interface{} {
+	m.addCall({{.callType}}{ {{.cvtParams}}})
+	if err := m.inject(params); err != nil {
+		return nil
+	}
+	m.mu.Lock()
+	fn := m.fnDo
+	m.mu.Unlock()
+	if fn != nil {
+		fn({{.cvtParams}})
+	}
+	return nil
+}
+{{- end}}
+
+// Spy observes the results of the real implementation, and returns the
+// results to use, which the spy function may have tweaked.
+func (m *{{.invoker}}) Spy(params []interface{}, results []interface{}) ([]interface{}, bool) {
+	m.mu.Lock()
+	when, fn := m.fnWhen, m.fnSpy
+	m.mu.Unlock()
+	if when != nil && !when({{.cvtParams}}) {
+		return results, false
+	}
+	if fn != nil {
+	{{- if .resp}}
+		{{.respOnlyArg}} := fn({{.spyArgs}})
+		results = {{.boxedResults}}
+	{{- else}}
+		fn({{.cvtParams}})
+	{{- end}}
+	}
+	m.addCall({{.callType}}{ {{.cvtParams}}})
+	m.captureArgs(params)
+	return results, true
 }
 
 // New{{.mockerName}} creates a new {{.mockerName}} instance.
-func New{{.mockerName}}[{{.req}} any, {{.resp}} any](r *Manager, typ reflect.Type, method string) *{{.mockerName}}[{{.req}}, {{.resp}}] {
-	m := &{{.mockerName}}[{{.req}}, {{.resp}}]{}
-	i := &{{.invokerName}}[{{.req}}, {{.resp}}]{ {{.mockerName}}: m}
-	r.AddMocker(typ, method, i)
+func New{{.mockerName}}{{.typeParams}}(r *Manager, typ reflect.Type, method string) *{{.mocker}} {
+	m := &{{.mocker}}{base: newBase(r, typ, method, {{.paramTypes}})}
+	i := &{{.invoker}}{ {{.mockerName}}: m}
+	m.reg = r.AddMocker(typ, method, i)
 	return m
 }
+
+// MockFunc{{.suffix}} creates a function of type F whose calls are handled by the
+// returned mocker, so that function values can be mocked without codegen.
+// F must be a function type of the form {{.funcSig}}, a variadic parameter
+// being a slice, and the mocker is registered with F as its type and name as
+// its method. The function panics if no mock matches a call.
+func MockFunc{{.suffix}}{{.funcTypeParams}}(r *Manager, name string) (F, *{{.mocker}}) {
+	fn := mockFunc[F](r, name, {{.paramTypes}}, {{.resultTypes}})
+	return fn, New{{.mockerName}}{{.typeArgs}}(r, reflect.TypeFor[F](), name)
+}
+`))
+
+var unboxTmpl = template.Must(template.New("unbox").Parse(`
+{{- if .resp}}
+// Unbox{{.count}} extracts {{.countText}} from a slice of interfaces. A mismatch
+// of their number or types is logged, a strict Manager reports it through its
+// test before, see Manager.SetStrict.
+func Unbox{{.count}}[{{.resp}} any](ret []interface{}) ({{.results}}) {
+	var err error
+	{{.vars}}, err = Unbox{{.count}}E[{{.resp}}](ret)
+	if err != nil {
+		reportUnbox(err)
+	}
+	return
+}
+
+// Unbox{{.count}}E is like Unbox{{.count}}, but returns the first mismatch as an error.
+func Unbox{{.count}}E[{{.resp}} any](ret []interface{}) ({{.results}}, err error) {
+	if err = checkCount(ret, {{.count}}); err != nil {
+		return
+	}
+	{{- range .cvtResults}}
+	{{.}}
+	{{- end}}
+	return
+}
+{{- else}}
+/********************************* Unbox *************************************/
+
+// Unbox0 checks that there is no return value in a slice of interfaces. A
+// mismatch is logged, a strict Manager reports it through its test before,
+// see Manager.SetStrict.
+func Unbox0(ret []interface{}) {
+	if err := Unbox0E(ret); err != nil {
+		reportUnbox(err)
+	}
+}
+
+// Unbox0E is like Unbox0, but returns the mismatch as an error.
+func Unbox0E(ret []interface{}) error {
+	return checkCount(ret, 0)
+}
+{{- end}}
 `))
 
+// countTexts describes the number of return values extracted by UnboxN.
+var countTexts = []string{
+	"no return value",
+	"a single return value",
+	"two return values",
+	"three return values",
+	"four return values",
+	"five return values",
+	"six return values",
+	"seven return values",
+	"eight return values",
+	"nine return values",
+	"ten return values",
+}
+
+// typeList returns the type parameter names with the given prefix, such as T1, T2.
+func typeList(prefix string, n int) []string {
+	ret := make([]string, n)
+	for k := 0; k < n; k++ {
+		ret[k] = prefix + fmt.Sprint(k+1)
+	}
+	return ret
+}
+
 // init sets the working directory of the application to the directory
 // where this source file resides.
 func init() {
@@ -113,7 +514,22 @@ func init() {
 	fmt.Println(workDir)
 }
 
+// maxArity is the largest supported arity, beyond it the names of
+// the mockers would be ambiguous, e.g. Mocker111 for 1-11 and 11-1.
+const maxArity = 10
+
 func main() {
+	var (
+		MaxParamCount  int
+		MaxResultCount int
+	)
+	flag.IntVar(&MaxParamCount, "params", maxArity, "max number of parameters")
+	flag.IntVar(&MaxResultCount, "results", maxArity, "max number of results")
+	flag.Parse()
+	if MaxParamCount < 0 || MaxParamCount > maxArity || MaxResultCount < 0 || MaxResultCount > maxArity {
+		panic(fmt.Sprintf("the number of parameters and results must be between 0 and %d", maxArity))
+	}
+
 	var s bytes.Buffer
 	s.WriteString(`/*
  * Copyright 2025 The Go-Spring Authors.
@@ -135,14 +551,12 @@ package gomock
 
 import (
 	"reflect"
+	"time"
+
+	"github.com/lvan100/gomock/gomock/match"
 )
 `)
 
-	const (
-		MaxParamCount  = 5
-		MaxResultCount = 5
-	)
-
 	s.WriteString(fmt.Sprintf(`
 	const (
 		MaxParamCount  = %d
@@ -150,33 +564,148 @@ import (
 	)
 `, MaxParamCount, MaxResultCount))
 
-	for i := 1; i <= MaxParamCount; i++ {
-		for j := 1; j <= MaxResultCount; j++ {
+	for i := 0; i <= MaxParamCount; i++ {
+		fields := make([]string, i)
+		args := make([]string, i)
+		for k := 0; k < i; k++ {
+			fields[k] = "P" + fmt.Sprint(k+1) + " T" + fmt.Sprint(k+1)
+			args[k] = "c.P" + fmt.Sprint(k+1)
+		}
+		data := map[string]interface{}{
+			"callName": fmt.Sprintf("Call%d", i),
+			"count":    i,
+			"req":      strings.Join(typeList("T", i), ", "),
+			"fields":   fields,
+			"args":     strings.Join(args, ", "),
+		}
+		err := callTmpl.Execute(&s, data)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for i := 0; i <= MaxParamCount; i++ {
+		for j := 0; j <= MaxResultCount; j++ {
 			mockerName := fmt.Sprintf("Mocker%d%d", i, j)
 			invokerName := fmt.Sprintf("Invoker%d%d", i, j)
-			req := make([]string, i)
+			req := strings.Join(typeList("T", i), ", ")
+			resp := strings.Join(typeList("R", j), ", ")
+
+			var typeParams, typeArgs []string
+			if i > 0 {
+				typeParams = append(typeParams, req+" any")
+				typeArgs = append(typeArgs, req)
+			}
+			if j > 0 {
+				typeParams = append(typeParams, resp+" any")
+				typeArgs = append(typeArgs, resp)
+			}
+			var typeParamList, typeArgList string
+			if len(typeParams) > 0 {
+				typeParamList = "[" + strings.Join(typeParams, ", ") + "]"
+				typeArgList = "[" + strings.Join(typeArgs, ", ") + "]"
+			}
+
+			callType := fmt.Sprintf("Call%d", i)
+			if i > 0 {
+				callType += "[" + req + "]"
+			}
+
+			respOnlyArg := strings.Join(typeList("r", j), ", ")
+			handleFunc := fmt.Sprintf("func(%s) bool", req)
+			handleVars := "ok"
+			boxedResults := "nil"
+			if j > 0 {
+				handleFunc = fmt.Sprintf("func(%s) (%s, bool)", req, resp)
+				handleVars = respOnlyArg + ", ok"
+				boxedResults = "[]interface{}{" + respOnlyArg + "}"
+			}
+
+			cvtParams := make([]string, i)
 			for k := 0; k < i; k++ {
-				req[k] = "T" + fmt.Sprint(k+1)
+				cvtParams[k] = fmt.Sprintf("paramAt[T%d](&m.base, params, %d)", k+1, k)
 			}
-			resp := make([]string, j)
+			cvtResults := make([]string, j)
 			for k := 0; k < j; k++ {
-				resp[k] = "R" + fmt.Sprint(k+1)
+				cvtResults[k] = fmt.Sprintf("resultAt[R%d](results, %d)", k+1, k)
 			}
-			respOnlyArg := make([]string, j)
-			for k := 0; k < j; k++ {
-				respOnlyArg[k] = "r" + fmt.Sprint(k+1)
+
+			paramTypes := "nil"
+			if i > 0 {
+				types := make([]string, i)
+				for k := 0; k < i; k++ {
+					types[k] = fmt.Sprintf("reflect.TypeFor[T%d]()", k+1)
+				}
+				paramTypes = "[]reflect.Type{" + strings.Join(types, ", ") + "}"
 			}
-			cvtParams := make([]string, i)
+
+			funcSig := fmt.Sprintf("func(%s)", req)
+			if j == 1 {
+				funcSig += " " + resp
+			} else if j > 1 {
+				funcSig += " (" + resp + ")"
+			}
+
+			resultTypes := "nil"
+			if j > 0 {
+				types := make([]string, j)
+				for k := 0; k < j; k++ {
+					types[k] = fmt.Sprintf("reflect.TypeFor[R%d]()", k+1)
+				}
+				resultTypes = "[]reflect.Type{" + strings.Join(types, ", ") + "}"
+			}
+
+			abortResults := "nil"
+			if j > 0 {
+				zeros := make([]string, j)
+				for k := 0; k < j-1; k++ {
+					zeros[k] = fmt.Sprintf("*new(R%d)", k+1)
+				}
+				zeros[j-1] = fmt.Sprintf("errorOrZero[R%d](err)", j)
+				abortResults = "[]interface{}{" + strings.Join(zeros, ", ") + "}"
+			}
+
+			spyFunc := fmt.Sprintf("func(%s)", req)
+			if j > 0 {
+				spyFunc = fmt.Sprintf("func(%s) (%s)", strings.Join(append(typeList("T", i), typeList("R", j)...), ", "), resp)
+			}
+			matchers := make([]string, i)
+			matcherArgs := make([]string, i)
+			values := make([]string, i)
+			matches := make([]string, i)
 			for k := 0; k < i; k++ {
-				cvtParams[k] = "params[" + fmt.Sprint(k) + "].(T" + fmt.Sprint(k+1) + ")"
+				matchers[k] = fmt.Sprintf("a%d match.Matcher[T%d]", k+1, k+1)
+				matcherArgs[k] = fmt.Sprintf("newArg(a%d)", k+1)
+				values[k] = fmt.Sprintf("v%d T%d", k+1, k+1)
+				matches[k] = fmt.Sprintf("a%d.Match(v%d)", k+1, k+1)
 			}
 			data := map[string]interface{}{
-				"mockerName":  mockerName,
-				"invokerName": invokerName,
-				"req":         strings.Join(req, ", "),
-				"resp":        strings.Join(resp, ", "),
-				"respOnlyArg": strings.Join(respOnlyArg, ", "),
-				"cvtParams":   strings.Join(cvtParams, ", "),
+				"matchers":       strings.Join(matchers, ", "),
+				"matcherArgs":    strings.Join(matcherArgs, ", "),
+				"values":         strings.Join(values, ", "),
+				"matches":        strings.Join(matches, " && "),
+				"callType":       callType,
+				"mockerName":     mockerName,
+				"invokerName":    invokerName,
+				"typeParams":     typeParamList,
+				"mocker":         mockerName + typeArgList,
+				"invoker":        invokerName + typeArgList,
+				"req":            req,
+				"resp":           resp,
+				"handleFunc":     handleFunc,
+				"handleVars":     handleVars,
+				"boxedResults":   boxedResults,
+				"respOnlyArg":    respOnlyArg,
+				"cvtParams":      strings.Join(cvtParams, ", "),
+				"abortResults":   abortResults,
+				"paramTypes":     paramTypes,
+				"resultTypes":    resultTypes,
+				"funcSig":        funcSig,
+				"suffix":         fmt.Sprintf("%d%d", i, j),
+				"typeArgs":       typeArgList,
+				"funcTypeParams": "[" + strings.Join(append([]string{"F any"}, typeParams...), ", ") + "]",
+				"spyFunc":        spyFunc,
+				"spyArgs":        strings.Join(append(cvtParams, cvtResults...), ", "),
 			}
 			err := mockerTmpl.Execute(&s, data)
 			if err != nil {
@@ -184,6 +713,28 @@ import (
 			}
 		}
 	}
+	for j := 0; j <= MaxResultCount; j++ {
+		resp := typeList("R", j)
+		results := make([]string, j)
+		cvtResults := make([]string, j)
+		for k := 0; k < j; k++ {
+			results[k] = fmt.Sprintf("r%d R%d", k+1, k+1)
+			cvtResults[k] = fmt.Sprintf("r%d, err = unboxAt[R%d](ret, %d, err)", k+1, k+1, k)
+		}
+		data := map[string]interface{}{
+			"count":      j,
+			"countText":  countTexts[j],
+			"resp":       strings.Join(resp, ", "),
+			"results":    strings.Join(results, ", "),
+			"vars":       strings.Join(typeList("r", j), ", "),
+			"cvtResults": cvtResults,
+		}
+		err := unboxTmpl.Execute(&s, data)
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	b, err := format.Source(s.Bytes())
 	if err != nil {
 		panic(err)
@@ -271,13 +271,40 @@ func mockInterface(s *bytes.Buffer, mi MockInterface) {
 		}
 		methodName := method.Names[0].Name
 		ft := method.Type.(*ast.FuncType)
-		paramCount := len(ft.Params.List)
+		params := flattenFields(ft.Params, "p")
+		paramCount := len(params)
+		results := flattenFields(ft.Results, "r")
+		resultCount := len(results)
+
+		// a method beyond the supported arity still implements the interface,
+		// but it can't be mocked, so it panics when called.
+		var unsupported string
 		if paramCount > gomock.MaxParamCount {
-			panic(fmt.Sprintf("have more than %d parameters", gomock.MaxParamCount))
+			unsupported = fmt.Sprintf("has more than %d parameters", gomock.MaxParamCount)
+		} else if resultCount > gomock.MaxResultCount {
+			unsupported = fmt.Sprintf("has more than %d results", gomock.MaxResultCount)
+		}
+		if unsupported != "" {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: %s.%s %s and can't be mocked\n", mi.Name, methodName, unsupported)
+		}
+		var (
+			paramDecls  []string
+			paramNames  []string
+			typeArgs    []string
+			resultTypes []string
+		)
+		for _, param := range params {
+			paramDecls = append(paramDecls, param.Name+" "+param.Type)
+			paramNames = append(paramNames, param.Name)
+			typeArgs = append(typeArgs, param.MockType)
+		}
+		for _, result := range results {
+			resultTypes = append(resultTypes, result.Type)
+			typeArgs = append(typeArgs, result.Type)
 		}
-		resultCount := len(ft.Results.List)
-		if resultCount > gomock.MaxResultCount {
-			panic(fmt.Sprintf("have more than %d results", gomock.MaxResultCount))
+		var typeArgList string
+		if len(typeArgs) > 0 {
+			typeArgList = "[" + strings.Join(typeArgs, ", ") + "]"
 		}
 		{
 			if len(mi.TypeParams) > 0 {
@@ -285,45 +312,39 @@ func mockInterface(s *bytes.Buffer, mi MockInterface) {
 			} else {
 				s.WriteString(fmt.Sprintf("\nfunc (impl *%sMockImpl) %s(", mi.Name, methodName))
 			}
-			for i, param := range ft.Params.List {
-				s.WriteString(param.Names[0].Name)
-				s.WriteString(" ")
-				s.WriteString(getTypeText(param.Type))
-				if i < len(ft.Params.List)-1 {
-					s.WriteString(", ")
-				}
-			}
+			s.WriteString(strings.Join(paramDecls, ", "))
 			s.WriteString(") (")
-			for i, result := range ft.Results.List {
-				s.WriteString(getTypeText(result.Type))
-				if i < len(ft.Results.List)-1 {
-					s.WriteString(", ")
-				}
-			}
+			s.WriteString(strings.Join(resultTypes, ", "))
 			s.WriteString(") {")
+			if unsupported != "" {
+				s.WriteString(fmt.Sprintf("\n\tpanic(\"%s.%s %s and can't be mocked\")", mi.Name, methodName, unsupported))
+				s.WriteString("\n}")
+				continue
+			}
 			if len(mi.TypeParams) > 0 {
 				s.WriteString(fmt.Sprintf("\n\tt := reflect.TypeFor[%sMockImpl[%s]]()", mi.Name, typeTokens))
 			} else {
 				s.WriteString(fmt.Sprintf("\n\tt := reflect.TypeFor[%sMockImpl]()", mi.Name))
 			}
-			s.WriteString(fmt.Sprintf("\n\tif ret, ok := gomock.Invoke(impl.r, t, \"%s\", ", methodName))
-			for i, param := range ft.Params.List {
-				s.WriteString(param.Names[0].Name)
-				if i < len(ft.Params.List)-1 {
-					s.WriteString(", ")
-				}
+			s.WriteString(fmt.Sprintf("\n\tif ret, ok := gomock.InvokeMock(impl.r, t, \"%s\"", methodName))
+			// a variadic parameter is passed as a single slice, not spread
+			for _, name := range paramNames {
+				s.WriteString(", " + name)
 			}
 			s.WriteString("); ok {")
-			s.WriteString(fmt.Sprintf("\n\t\treturn gomock.Unbox%d[", resultCount))
-			for i, result := range ft.Results.List {
-				s.WriteString(getTypeText(result.Type))
-				if i < len(ft.Results.List)-1 {
-					s.WriteString(", ")
+			if resultCount == 0 {
+				s.WriteString("\n\t\tgomock.Unbox0(ret)")
+				s.WriteString("\n\t}")
+			} else {
+				s.WriteString(fmt.Sprintf("\n\t\treturn gomock.Unbox%d[%s](ret)", resultCount, strings.Join(resultTypes, ", ")))
+				s.WriteString("\n\t}")
+				// InvokeMock has reported the unmatched call through the test
+				zeros := make([]string, resultCount)
+				for i, typ := range resultTypes {
+					zeros[i] = "*new(" + typ + ")"
 				}
+				s.WriteString("\n\treturn " + strings.Join(zeros, ", "))
 			}
-			s.WriteString("](ret)")
-			s.WriteString("\n\t}")
-			s.WriteString("\n\tpanic(\"no mock code matched\")")
 			s.WriteString("\n}")
 		}
 		s.WriteString("\n")
@@ -333,46 +354,54 @@ func mockInterface(s *bytes.Buffer, mi MockInterface) {
 			} else {
 				s.WriteString(fmt.Sprintf("\nfunc (impl *%sMockImpl) Mock%s(", mi.Name, methodName))
 			}
-			s.WriteString(fmt.Sprintf(") *gomock.Mocker%d%d[", paramCount, resultCount))
-			for i, param := range ft.Params.List {
-				s.WriteString(getTypeText(param.Type))
-				if i < len(ft.Params.List)-1 {
-					s.WriteString(", ")
-				}
-			}
-			s.WriteString(", ")
-			for i, result := range ft.Results.List {
-				s.WriteString(getTypeText(result.Type))
-				if i < len(ft.Results.List)-1 {
-					s.WriteString(", ")
-				}
-			}
-			s.WriteString("] {")
+			s.WriteString(fmt.Sprintf(") *gomock.Mocker%d%d%s {", paramCount, resultCount, typeArgList))
 			if len(mi.TypeParams) > 0 {
 				s.WriteString(fmt.Sprintf("\n\tt := reflect.TypeFor[%sMockImpl[%s]]()", mi.Name, typeTokens))
 			} else {
 				s.WriteString(fmt.Sprintf("\n\tt := reflect.TypeFor[%sMockImpl]()", mi.Name))
 			}
-			s.WriteString(fmt.Sprintf("\n\treturn gomock.NewMocker%d%d[", paramCount, resultCount))
-			for i, param := range ft.Params.List {
-				s.WriteString(getTypeText(param.Type))
-				if i < len(ft.Params.List)-1 {
-					s.WriteString(", ")
-				}
-			}
-			s.WriteString(", ")
-			for i, result := range ft.Results.List {
-				s.WriteString(getTypeText(result.Type))
-				if i < len(ft.Results.List)-1 {
-					s.WriteString(", ")
-				}
-			}
-			s.WriteString(fmt.Sprintf("](impl.r, t, \"%s\")", methodName))
+			s.WriteString(fmt.Sprintf("\n\treturn gomock.NewMocker%d%d%s(impl.r, t, \"%s\")", paramCount, resultCount, typeArgList, methodName))
 			s.WriteString("\n}")
 		}
 	}
 }
 
+// funcParam is a parameter or a result of a method.
+type funcParam struct {
+	Name     string
+	Type     string // the type in the method signature, such as "...any"
+	MockType string // the type seen by the mocker, such as "[]any"
+}
+
+// flattenFields returns one funcParam per name in the field list, unnamed
+// fields are given a name made of the prefix and their position.
+func flattenFields(fields *ast.FieldList, prefix string) []funcParam {
+	if fields == nil {
+		return nil
+	}
+	var ret []funcParam
+	for _, field := range fields.List {
+		typeText := getTypeText(field.Type)
+		mockType := typeText
+		if t, ok := field.Type.(*ast.Ellipsis); ok {
+			mockType = "[]" + getTypeText(t.Elt)
+		}
+		if len(field.Names) == 0 {
+			name := fmt.Sprintf("%s%d", prefix, len(ret))
+			ret = append(ret, funcParam{Name: name, Type: typeText, MockType: mockType})
+			continue
+		}
+		for _, ident := range field.Names {
+			name := ident.Name
+			if name == "_" {
+				name = fmt.Sprintf("%s%d", prefix, len(ret))
+			}
+			ret = append(ret, funcParam{Name: name, Type: typeText, MockType: mockType})
+		}
+	}
+	return ret
+}
+
 var (
 	typeTextBuffer  bytes.Buffer
 	typeTextFileSet = token.NewFileSet()
@@ -33,6 +33,19 @@ type Service interface {
 	Get(ctx context.Context, req *inner.Request, params map[string]string) (*Response, error)
 }
 
+type Conn interface {
+	Flush(ctx context.Context)
+	Close() error
+	Reset()
+	Exec(ctx context.Context, query string, args ...any) (int64, error)
+	Log(args ...any)
+}
+
+type Store interface {
+	Query(ctx context.Context, table string, columns []string, where string, orderBy string, limit, offset int) ([]map[string]any, int, error)
+	Huge(a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11 int)
+}
+
 type Repository[T any] interface {
 	Save(item T) error
 	FindByID(id string) (T, error)
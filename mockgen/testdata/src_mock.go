@@ -19,10 +19,10 @@ func NewServiceMockImpl(r *gomock.Manager) *ServiceMockImpl {
 
 func (impl *ServiceMockImpl) Get(ctx context.Context, req *inner.Request, params map[string]string) (*Response, error) {
 	t := reflect.TypeFor[ServiceMockImpl]()
-	if ret, ok := gomock.Invoke(impl.r, t, "Get", ctx, req, params); ok {
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Get", ctx, req, params); ok {
 		return gomock.Unbox2[*Response, error](ret)
 	}
-	panic("no mock code matched")
+	return *new(*Response), *new(error)
 }
 
 func (impl *ServiceMockImpl) MockGet() *gomock.Mocker32[context.Context, *inner.Request, map[string]string, *Response, error] {
@@ -30,6 +30,101 @@ func (impl *ServiceMockImpl) MockGet() *gomock.Mocker32[context.Context, *inner.
 	return gomock.NewMocker32[context.Context, *inner.Request, map[string]string, *Response, error](impl.r, t, "Get")
 }
 
+type ConnMockImpl struct {
+	r *gomock.Manager
+}
+
+func NewConnMockImpl(r *gomock.Manager) *ConnMockImpl {
+	return &ConnMockImpl{r}
+}
+
+func (impl *ConnMockImpl) Flush(ctx context.Context) {
+	t := reflect.TypeFor[ConnMockImpl]()
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Flush", ctx); ok {
+		gomock.Unbox0(ret)
+	}
+}
+
+func (impl *ConnMockImpl) MockFlush() *gomock.Mocker10[context.Context] {
+	t := reflect.TypeFor[ConnMockImpl]()
+	return gomock.NewMocker10[context.Context](impl.r, t, "Flush")
+}
+
+func (impl *ConnMockImpl) Close() error {
+	t := reflect.TypeFor[ConnMockImpl]()
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Close"); ok {
+		return gomock.Unbox1[error](ret)
+	}
+	return *new(error)
+}
+
+func (impl *ConnMockImpl) MockClose() *gomock.Mocker01[error] {
+	t := reflect.TypeFor[ConnMockImpl]()
+	return gomock.NewMocker01[error](impl.r, t, "Close")
+}
+
+func (impl *ConnMockImpl) Reset() {
+	t := reflect.TypeFor[ConnMockImpl]()
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Reset"); ok {
+		gomock.Unbox0(ret)
+	}
+}
+
+func (impl *ConnMockImpl) MockReset() *gomock.Mocker00 {
+	t := reflect.TypeFor[ConnMockImpl]()
+	return gomock.NewMocker00(impl.r, t, "Reset")
+}
+
+func (impl *ConnMockImpl) Exec(ctx context.Context, query string, args ...any) (int64, error) {
+	t := reflect.TypeFor[ConnMockImpl]()
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Exec", ctx, query, args); ok {
+		return gomock.Unbox2[int64, error](ret)
+	}
+	return *new(int64), *new(error)
+}
+
+func (impl *ConnMockImpl) MockExec() *gomock.Mocker32[context.Context, string, []any, int64, error] {
+	t := reflect.TypeFor[ConnMockImpl]()
+	return gomock.NewMocker32[context.Context, string, []any, int64, error](impl.r, t, "Exec")
+}
+
+func (impl *ConnMockImpl) Log(args ...any) {
+	t := reflect.TypeFor[ConnMockImpl]()
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Log", args); ok {
+		gomock.Unbox0(ret)
+	}
+}
+
+func (impl *ConnMockImpl) MockLog() *gomock.Mocker10[[]any] {
+	t := reflect.TypeFor[ConnMockImpl]()
+	return gomock.NewMocker10[[]any](impl.r, t, "Log")
+}
+
+type StoreMockImpl struct {
+	r *gomock.Manager
+}
+
+func NewStoreMockImpl(r *gomock.Manager) *StoreMockImpl {
+	return &StoreMockImpl{r}
+}
+
+func (impl *StoreMockImpl) Query(ctx context.Context, table string, columns []string, where string, orderBy string, limit int, offset int) ([]map[string]any, int, error) {
+	t := reflect.TypeFor[StoreMockImpl]()
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Query", ctx, table, columns, where, orderBy, limit, offset); ok {
+		return gomock.Unbox3[[]map[string]any, int, error](ret)
+	}
+	return *new([]map[string]any), *new(int), *new(error)
+}
+
+func (impl *StoreMockImpl) MockQuery() *gomock.Mocker73[context.Context, string, []string, string, string, int, int, []map[string]any, int, error] {
+	t := reflect.TypeFor[StoreMockImpl]()
+	return gomock.NewMocker73[context.Context, string, []string, string, string, int, int, []map[string]any, int, error](impl.r, t, "Query")
+}
+
+func (impl *StoreMockImpl) Huge(a1 int, a2 int, a3 int, a4 int, a5 int, a6 int, a7 int, a8 int, a9 int, a10 int, a11 int) {
+	panic("Store.Huge has more than 10 parameters and can't be mocked")
+}
+
 type RepositoryMockImpl[T any] struct {
 	r *gomock.Manager
 }
@@ -40,10 +135,10 @@ func NewRepositoryMockImpl[T any](r *gomock.Manager) *RepositoryMockImpl[T] {
 
 func (impl *RepositoryMockImpl[T]) Save(item T) error {
 	t := reflect.TypeFor[RepositoryMockImpl[T]]()
-	if ret, ok := gomock.Invoke(impl.r, t, "Save", item); ok {
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Save", item); ok {
 		return gomock.Unbox1[error](ret)
 	}
-	panic("no mock code matched")
+	return *new(error)
 }
 
 func (impl *RepositoryMockImpl[T]) MockSave() *gomock.Mocker11[T, error] {
@@ -53,10 +148,10 @@ func (impl *RepositoryMockImpl[T]) MockSave() *gomock.Mocker11[T, error] {
 
 func (impl *RepositoryMockImpl[T]) FindByID(id string) (T, error) {
 	t := reflect.TypeFor[RepositoryMockImpl[T]]()
-	if ret, ok := gomock.Invoke(impl.r, t, "FindByID", id); ok {
+	if ret, ok := gomock.InvokeMock(impl.r, t, "FindByID", id); ok {
 		return gomock.Unbox2[T, error](ret)
 	}
-	panic("no mock code matched")
+	return *new(T), *new(error)
 }
 
 func (impl *RepositoryMockImpl[T]) MockFindByID() *gomock.Mocker12[string, T, error] {
@@ -74,10 +169,10 @@ func NewRepositoryV2MockImpl[T ~int | ~uint](r *gomock.Manager) *RepositoryV2Moc
 
 func (impl *RepositoryV2MockImpl[T]) Save(item T) error {
 	t := reflect.TypeFor[RepositoryV2MockImpl[T]]()
-	if ret, ok := gomock.Invoke(impl.r, t, "Save", item); ok {
+	if ret, ok := gomock.InvokeMock(impl.r, t, "Save", item); ok {
 		return gomock.Unbox1[error](ret)
 	}
-	panic("no mock code matched")
+	return *new(error)
 }
 
 func (impl *RepositoryV2MockImpl[T]) MockSave() *gomock.Mocker11[T, error] {
@@ -87,10 +182,10 @@ func (impl *RepositoryV2MockImpl[T]) MockSave() *gomock.Mocker11[T, error] {
 
 func (impl *RepositoryV2MockImpl[T]) FindByID(id string) (T, error) {
 	t := reflect.TypeFor[RepositoryV2MockImpl[T]]()
-	if ret, ok := gomock.Invoke(impl.r, t, "FindByID", id); ok {
+	if ret, ok := gomock.InvokeMock(impl.r, t, "FindByID", id); ok {
 		return gomock.Unbox2[T, error](ret)
 	}
-	panic("no mock code matched")
+	return *new(T), *new(error)
 }
 
 func (impl *RepositoryV2MockImpl[T]) MockFindByID() *gomock.Mocker12[string, T, error] {
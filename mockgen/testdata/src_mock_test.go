@@ -18,9 +18,13 @@ package testdata
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/lvan100/gomock/gomock"
+	"github.com/lvan100/gomock/gomock/match"
 	"github.com/lvan100/gomock/internal/assert"
 	"github.com/lvan100/gomock/mockgen/testdata/inner"
 )
@@ -44,6 +48,52 @@ func TestMock(t *testing.T) {
 	assert.Equal(t, count, 2)
 }
 
+// recorder is a testing.TB that records the reported errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Context() context.Context {
+	return context.Background()
+}
+
+func (r *recorder) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func TestUnmatchedMock(t *testing.T) {
+	var rec recorder
+	r, ctx := gomock.New(&rec)
+	impl := NewServiceMockImpl(r)
+	impl.MockGet().
+		When(func(ctx context.Context, req *inner.Request, m map[string]string) bool {
+			return m["id"] == "1"
+		}).
+		Return(func() (*Response, error) {
+			return &Response{}, nil
+		})
+
+	resp, err := impl.Get(ctx, nil, map[string]string{"id": "2"})
+	assert.Nil(t, resp)
+	assert.Nil(t, err)
+	assert.Equal(t, len(rec.errors), 1)
+	assert.Equal(t, strings.HasPrefix(rec.errors[0], "gomock: testdata.ServiceMockImpl.Get: no mock matched the call"), true)
+
+	// the call reported when made is not reported again at the end of the test
+	for i := len(rec.cleanups) - 1; i >= 0; i-- {
+		rec.cleanups[i]()
+	}
+	assert.Equal(t, len(rec.errors), 1)
+}
+
 func TestGenericMock(t *testing.T) {
 	r, _ := gomock.Init(t.Context())
 	impl := NewRepositoryMockImpl[int](r)
@@ -58,3 +108,90 @@ func TestGenericMock(t *testing.T) {
 	_ = impl.Save(1)
 	assert.Equal(t, count, 2)
 }
+
+func TestZeroParamsOrResults(t *testing.T) {
+	r, ctx := gomock.Init(t.Context())
+	impl := NewConnMockImpl(r)
+
+	var flushed context.Context
+	impl.MockFlush().
+		When(func(ctx context.Context) bool {
+			return true
+		}).
+		Do(func(ctx context.Context) {
+			flushed = ctx
+		})
+	impl.Flush(ctx)
+	assert.Equal(t, flushed, ctx)
+
+	impl.MockClose().
+		When(func() bool {
+			return true
+		}).
+		Return(func() error {
+			return errors.New("closed")
+		})
+	assert.Equal(t, impl.Close().Error(), "closed")
+
+	m := impl.MockReset().When(func() bool {
+		return true
+	})
+	impl.Reset()
+	impl.Reset()
+	assert.Equal(t, len(m.Calls()), 2)
+}
+
+func TestVariadic(t *testing.T) {
+	r, ctx := gomock.Init(t.Context())
+	impl := NewConnMockImpl(r)
+
+	impl.MockExec().
+		WhenArgs(
+			match.Any[context.Context](),
+			match.Regexp("^UPDATE"),
+			match.Elems(match.Eq[any]("a"), match.Type[int, any]()),
+		).
+		Return(func() (int64, error) {
+			return 1, nil
+		})
+	n, err := impl.Exec(ctx, "UPDATE t SET a = ? WHERE b = ?", "a", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, n, int64(1))
+	assert.Panic(t, func() {
+		_, _ = impl.Exec(ctx, "UPDATE t SET a = ?", "a")
+	}, "no mock code matched")
+
+	var logged [][]any
+	impl.MockLog().
+		When(func(args []any) bool {
+			return true
+		}).
+		Do(func(args []any) {
+			logged = append(logged, args)
+		})
+	impl.Log()
+	impl.Log("a", 1)
+	assert.Equal(t, logged, [][]any{nil, {"a", 1}})
+}
+
+func TestManyParams(t *testing.T) {
+	r, ctx := gomock.Init(t.Context())
+	impl := NewStoreMockImpl(r)
+
+	impl.MockQuery().
+		When(func(ctx context.Context, table string, columns []string, where string, orderBy string, limit int, offset int) bool {
+			return table == "users" && limit == 10
+		}).
+		Return(func() ([]map[string]any, int, error) {
+			return []map[string]any{{"id": 1}}, 1, nil
+		})
+
+	rows, total, err := impl.Query(ctx, "users", []string{"id"}, "", "id", 10, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, total, 1)
+	assert.Equal(t, rows, []map[string]any{{"id": 1}})
+
+	assert.Panic(t, func() {
+		impl.Huge(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11)
+	}, "Store.Huge has more than 10 parameters and can't be mocked")
+}